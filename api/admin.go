@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres"
+)
+
+// runtimeStatus is served at /debug/status on the admin listener. It's meant
+// for diagnosing a stuck production process without attaching a debugger:
+// goroutine counts point at leaks or deadlocks, and the DB health snapshot
+// shows whether the importer is still advancing.
+type runtimeStatus struct {
+	NumGoroutine  int                   `json:"num-goroutine"`
+	NumCPU        int                   `json:"num-cpu"`
+	DBHealth      idb.Health            `json:"db-health"`
+	ResponseCache map[string]cacheStats `json:"response-cache"`
+}
+
+// jobLister is implemented by idb.IndexerDb backends that support the
+// background job framework (currently only postgres.IndexerDb). /debug/jobs
+// reports an empty list rather than an error when db doesn't implement it.
+type jobLister interface {
+	ListJobs(ctx context.Context) ([]postgres.Job, error)
+}
+
+// jobCanceler is implemented by idb.IndexerDb backends that support
+// cancelling a background job.
+type jobCanceler interface {
+	CancelJob(ctx context.Context, name string) error
+}
+
+// metastateReporter is implemented by idb.IndexerDb backends that can report
+// their typed metastate (currently only postgres.IndexerDb). /debug/metastate
+// reports an empty object rather than an error when db doesn't implement it.
+type metastateReporter interface {
+	Metastate(ctx context.Context) (postgres.Metastate, error)
+}
+
+// serveAdmin starts the admin listener exposing net/http/pprof,
+// /debug/status, and /debug/jobs. It's expected to be bound to a private
+// address (e.g. localhost, or a cluster-internal interface) since it has no
+// auth of its own and pprof profiling isn't free. Serve blocks until ctx is
+// cancelled.
+func serveAdmin(ctx context.Context, listenAddr string, db idb.IndexerDb, log *log.Logger, responseCaches map[string]*lruCache) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("/debug/status", func(w http.ResponseWriter, r *http.Request) {
+		status := runtimeStatus{
+			NumGoroutine:  runtime.NumGoroutine(),
+			NumCPU:        runtime.NumCPU(),
+			ResponseCache: make(map[string]cacheStats, len(responseCaches)),
+		}
+		if h, err := db.Health(r.Context()); err == nil {
+			status.DBHealth = h
+		}
+		for name, cache := range responseCaches {
+			status.ResponseCache[name] = cache.stats()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	})
+
+	mux.HandleFunc("/debug/jobs", func(w http.ResponseWriter, r *http.Request) {
+		jobs := []postgres.Job{}
+		if lister, ok := db.(jobLister); ok {
+			if dbJobs, err := lister.ListJobs(r.Context()); err == nil {
+				jobs = dbJobs
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jobs)
+	})
+
+	mux.HandleFunc("/debug/jobs/cancel", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		canceler, ok := db.(jobCanceler)
+		if !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		if err := canceler.CancelJob(r.Context(), name); err != nil {
+			log.Warnf("/debug/jobs/cancel: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/debug/metastate", func(w http.ResponseWriter, r *http.Request) {
+		var state postgres.Metastate
+		if reporter, ok := db.(metastateReporter); ok {
+			if s, err := reporter.Metastate(r.Context()); err == nil {
+				state = s
+			} else {
+				log.Warnf("/debug/metastate: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(state)
+	})
+
+	s := &http.Server{
+		Addr:    listenAddr,
+		Handler: mux,
+	}
+
+	go func() {
+		log.Infof("admin listener (pprof, /debug/status, /debug/jobs) serving on %s", listenAddr)
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Warnf("admin listener error: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("admin listener shutdown error: %v", err)
+	}
+}
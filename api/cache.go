@@ -0,0 +1,92 @@
+package api
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a small, fixed-capacity, in-process cache for hot read-only
+// API responses (block headers, transactions by ID, asset params). It has no
+// explicit invalidation - callers pick a key that's only ever valid for the
+// data it maps to (e.g. folding in the round a response was computed as of),
+// and stale entries simply age out as newer keys push them past capacity.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+
+	hits, misses uint64
+}
+
+type lruCacheEntry struct {
+	key   string
+	value interface{}
+}
+
+// newLRUCache builds a cache holding at most capacity entries. capacity <= 0
+// disables caching: get always misses and add is a no-op.
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key string) (interface{}, bool) {
+	if c == nil || c.capacity <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*lruCacheEntry).value, true
+}
+
+func (c *lruCache) add(key string, value interface{}) {
+	if c == nil || c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*lruCacheEntry).value = value
+		return
+	}
+
+	elem := c.ll.PushFront(&lruCacheEntry{key: key, value: value})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruCacheEntry).key)
+		}
+	}
+}
+
+// cacheStats is a point-in-time snapshot of hit/miss counts, served at
+// /debug/status on the admin listener.
+type cacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+func (c *lruCache) stats() cacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return cacheStats{Hits: c.hits, Misses: c.misses}
+}
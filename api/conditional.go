@@ -0,0 +1,48 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// immutableCacheControl is applied to responses served through
+// respondWithETag. These resources (a confirmed block, a confirmed
+// transaction) never change once they exist, so caching is safe for as long
+// as a client or CDN cares to keep it.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// respondWithETag serves payload as JSON with an ETag and Cache-Control
+// header, short-circuiting to a bodyless 304 when the request's
+// If-None-Match already matches. etag should uniquely identify the
+// resource's content, e.g. a round number or a txid, since the caller is
+// asserting the underlying data is immutable.
+func respondWithETag(ctx echo.Context, etag string, payload interface{}) error {
+	quoted := fmt.Sprintf("%q", etag)
+
+	ctx.Response().Header().Set("ETag", quoted)
+	ctx.Response().Header().Set(echo.HeaderCacheControl, immutableCacheControl)
+
+	if ifNoneMatchHasETag(ctx.Request().Header.Get("If-None-Match"), quoted) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	return ctx.JSON(http.StatusOK, payload)
+}
+
+// ifNoneMatchHasETag reports whether the (possibly comma-separated)
+// If-None-Match header contains etag or "*".
+func ifNoneMatchHasETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"crypto/sha512"
 	"encoding/base32"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -88,6 +90,124 @@ func decodeAddressRole(role *string, excludeCloseTo *bool, errorArr []string) (i
 	return 0, append(errorArr, fmt.Sprintf("%s: '%s'", errUnknownAddressRole, lc))
 }
 
+//////////////////////////////////////////////////////////////////
+// Response re-encoding helpers, for alternative id/address forms //
+//////////////////////////////////////////////////////////////////
+
+// applyAddressFormat rewrites each account's Address (and AuthAddr, if
+// present) from the default checksummed base32 form to hex when useHex is
+// set, and/or adds the raw 32-byte public key as PublicKey when includeRaw
+// is set, to ease integration with systems that store account keys as raw
+// binary rather than the checksummed address string.
+func applyAddressFormat(accounts []generated.Account, useHex, includeRaw bool) error {
+	if !useHex && !includeRaw {
+		return nil
+	}
+	for i := range accounts {
+		addr, err := basics.UnmarshalChecksumAddress(accounts[i].Address)
+		if err != nil {
+			return fmt.Errorf("%s '%s': %w", errUnableToParseAddress, accounts[i].Address, err)
+		}
+		if includeRaw {
+			raw := append([]byte(nil), addr[:]...)
+			accounts[i].PublicKey = &raw
+		}
+		if useHex {
+			accounts[i].Address = hex.EncodeToString(addr[:])
+			if accounts[i].AuthAddr != nil {
+				authAddr, err := basics.UnmarshalChecksumAddress(*accounts[i].AuthAddr)
+				if err != nil {
+					return fmt.Errorf("%s '%s': %w", errUnableToParseAddress, *accounts[i].AuthAddr, err)
+				}
+				hexAuthAddr := hex.EncodeToString(authAddr[:])
+				accounts[i].AuthAddr = &hexAuthAddr
+			}
+		}
+	}
+	return nil
+}
+
+// applyTxidFormat rewrites each transaction's Id from the default base32
+// digest form to hex when useHex is set, to ease integration with systems
+// that store transaction IDs as raw binary.
+func applyTxidFormat(txns []generated.Transaction, useHex bool) error {
+	if !useHex {
+		return nil
+	}
+	for i := range txns {
+		if txns[i].Id == nil {
+			continue
+		}
+		raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(*txns[i].Id)
+		if err != nil {
+			return fmt.Errorf("%s '%s': %w", errUnableToParseDigest, *txns[i].Id, err)
+		}
+		hexID := hex.EncodeToString(raw)
+		txns[i].Id = &hexID
+	}
+	return nil
+}
+
+// elideLargeFields replaces the note field and any approval-program,
+// clear-state-program, or logicsig logic field (including on inner
+// transactions) whose length exceeds maxSize with its size and a
+// sha512/256 checksum, so a search response stays lightweight even when it
+// matches transactions carrying large notes or programs. The full object is
+// still available uneliced via lookup-by-txid.
+func elideLargeFields(txns []generated.Transaction, maxSize uint64) {
+	for i := range txns {
+		elideLargeFieldsInTransaction(&txns[i], maxSize)
+	}
+}
+
+func elideLargeFieldsInTransaction(txn *generated.Transaction, maxSize uint64) {
+	if txn.Note != nil && uint64(len(*txn.Note)) > maxSize {
+		size := uint64(len(*txn.Note))
+		sum := sha512.Sum512_256(*txn.Note)
+		checksum := sum[:]
+		txn.NoteSize = &size
+		txn.NoteChecksum = &checksum
+		txn.Note = nil
+	}
+
+	if app := txn.ApplicationTransaction; app != nil {
+		if app.ApprovalProgram != nil && uint64(len(*app.ApprovalProgram)) > maxSize {
+			size := uint64(len(*app.ApprovalProgram))
+			sum := sha512.Sum512_256(*app.ApprovalProgram)
+			checksum := sum[:]
+			app.ApprovalProgramSize = &size
+			app.ApprovalProgramChecksum = &checksum
+			app.ApprovalProgram = nil
+		}
+		if app.ClearStateProgram != nil && uint64(len(*app.ClearStateProgram)) > maxSize {
+			size := uint64(len(*app.ClearStateProgram))
+			sum := sha512.Sum512_256(*app.ClearStateProgram)
+			checksum := sum[:]
+			app.ClearStateProgramSize = &size
+			app.ClearStateProgramChecksum = &checksum
+			app.ClearStateProgram = nil
+		}
+	}
+
+	if txn.Signature != nil && txn.Signature.Logicsig != nil {
+		lsig := txn.Signature.Logicsig
+		if uint64(len(lsig.Logic)) > maxSize {
+			size := uint64(len(lsig.Logic))
+			sum := sha512.Sum512_256(lsig.Logic)
+			checksum := sum[:]
+			lsig.LogicSize = &size
+			lsig.LogicChecksum = &checksum
+			lsig.Logic = nil
+		}
+	}
+
+	if txn.InnerTxns != nil {
+		for i := range *txn.InnerTxns {
+			elideLargeFieldsInTransaction(&(*txn.InnerTxns)[i], maxSize)
+		}
+	}
+}
+
 const (
 	addrRoleSender   = "sender"
 	addrRoleReceiver = "receiver"
@@ -100,17 +220,45 @@ var addressRoleEnumMap = map[string]bool{
 	addrRoleFreeze:   true,
 }
 
+// base64Encodings are the encodings tried, in order, by decodeBase64Byte.
+// Standard base64 is tried first since it's what the rest of the API uses;
+// the base64url variants are accepted too since note-prefix values are often
+// copied out of URLs, where '+' and '/' would already have been mangled.
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding,
+	base64.RawStdEncoding,
+	base64.URLEncoding,
+	base64.RawURLEncoding,
+}
+
 func decodeBase64Byte(str *string, field string, errorArr []string) ([]byte, []string) {
 	if str != nil {
-		data, err := base64.StdEncoding.DecodeString(*str)
-		if err != nil {
-			return nil, append(errorArr, fmt.Sprintf("%s: '%s'", errUnableToParseBase64, field))
+		for _, enc := range base64Encodings {
+			if data, err := enc.DecodeString(*str); err == nil {
+				return data, errorArr
+			}
 		}
-		return data, errorArr
+		return nil, append(errorArr, fmt.Sprintf("%s: '%s'", errUnableToParseBase64, field))
 	}
 	return nil, errorArr
 }
 
+// decodeGroupID decodes a base64 atomic transaction group id, verifying that
+// it unpacks to the 32 bytes of a digest, or appends an error to errorArr
+func decodeGroupID(str *string, errorArr []string) ([]byte, []string) {
+	if str == nil {
+		return nil, errorArr
+	}
+	groupID, errorArr := decodeBase64Byte(str, "group-id", errorArr)
+	if groupID == nil {
+		return nil, errorArr
+	}
+	if len(groupID) != len(sdk.Digest{}) {
+		return nil, append(errorArr, fmt.Sprintf("%s: '%s'", errUnableToParseGroupID, *str))
+	}
+	return groupID, errorArr
+}
+
 // decodeSigType validates the input string and dereferences it if present, or appends an error to errorArr
 func decodeSigType(str *string, errorArr []string) (idb.SigType, []string) {
 	if str != nil {
@@ -138,6 +286,71 @@ func decodeType(str *string, errorArr []string) (t idb.TxnTypeEnum, err []string
 	return 0, errorArr
 }
 
+//////////////////////////////////////////////////////////////////
+// Versioned next-token helpers for keyset-paginated endpoints //
+//////////////////////////////////////////////////////////////////
+
+// nextTokenV1Prefix marks a next-token as the current, keyset-pagination
+// format: a single unsigned integer cursor (e.g. "the last ID returned")
+// used as a strict greater-than bound on the next query. Bumping the
+// version lets a future change to what a token encodes reject tokens from
+// an incompatible release instead of misparsing them.
+const nextTokenV1Prefix = "enc1__"
+
+// encodeUintNextToken formats a keyset-pagination cursor as a versioned,
+// self-describing next-token.
+func encodeUintNextToken(value uint64) string {
+	return nextTokenV1Prefix + strconv.FormatUint(value, 10)
+}
+
+// decodeUintNextToken parses a next-token produced by encodeUintNextToken,
+// rejecting one from a missing or incompatible format version with a clear
+// error rather than misinterpreting it.
+func decodeUintNextToken(token string) (uint64, error) {
+	if !strings.HasPrefix(token, nextTokenV1Prefix) {
+		return 0, errors.New(errUnsupportedNextTokenVersion)
+	}
+	value, err := strconv.ParseUint(strings.TrimPrefix(token, nextTokenV1Prefix), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", errUnableToParseNext, err)
+	}
+	return value, nil
+}
+
+// orderedAccountNextTokenV1Prefix marks a next-token as a keyset-pagination
+// cursor for accounts sorted by something other than address: the sort
+// column's value, then the address used to break ties between accounts
+// sharing that value.
+const orderedAccountNextTokenV1Prefix = "enc1acct__"
+
+// orderedAccountNextTokenSep separates the two parts of the cursor. It can't
+// appear in a base32-encoded address, so splitting on it is unambiguous.
+const orderedAccountNextTokenSep = "__"
+
+// encodeOrderedAccountNextToken formats a keyset-pagination cursor for an
+// accounts search sorted by orderValue, breaking ties on addr.
+func encodeOrderedAccountNextToken(orderValue uint64, addr string) string {
+	return orderedAccountNextTokenV1Prefix + strconv.FormatUint(orderValue, 10) + orderedAccountNextTokenSep + addr
+}
+
+// decodeOrderedAccountNextToken parses a next-token produced by
+// encodeOrderedAccountNextToken.
+func decodeOrderedAccountNextToken(token string) (uint64, string, error) {
+	if !strings.HasPrefix(token, orderedAccountNextTokenV1Prefix) {
+		return 0, "", errors.New(errUnsupportedNextTokenVersion)
+	}
+	rest := strings.TrimPrefix(token, orderedAccountNextTokenV1Prefix)
+	parts := strings.SplitN(rest, orderedAccountNextTokenSep, 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("%s: malformed ordered account next-token", errUnableToParseNext)
+	}
+	value, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %v", errUnableToParseNext, err)
+	}
+	return value, parts[1], nil
+}
+
 ////////////////////////////////////////////////////
 // Helpers to convert to and from generated types //
 ////////////////////////////////////////////////////
@@ -630,22 +843,32 @@ func (si *ServerImplementation) assetParamsToAssetQuery(params generated.SearchF
 
 	var assetGreaterThan uint64 = 0
 	if params.Next != nil {
-		agt, err := strconv.ParseUint(*params.Next, 10, 64)
+		agt, err := decodeUintNextToken(*params.Next)
 		if err != nil {
-			return idb.AssetsQuery{}, fmt.Errorf("%s: %v", errUnableToParseNext, err)
+			return idb.AssetsQuery{}, err
 		}
 		assetGreaterThan = agt
 	}
 
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultAssetsLimit, si.opts.MaxAssetsLimit)
+	if err != nil {
+		return idb.AssetsQuery{}, err
+	}
+
 	query := idb.AssetsQuery{
-		AssetID:            uintOrDefault(params.AssetId),
-		AssetIDGreaterThan: assetGreaterThan,
-		Creator:            creator,
-		Name:               strOrDefault(params.Name),
-		Unit:               strOrDefault(params.Unit),
-		Query:              "",
-		IncludeDeleted:     boolOrDefault(params.IncludeAll),
-		Limit:              min(uintOrDefaultValue(params.Limit, si.opts.DefaultAssetsLimit), si.opts.MaxAssetsLimit),
+		AssetID:              uintOrDefault(params.AssetId),
+		AssetIDGreaterThan:   assetGreaterThan,
+		Creator:              creator,
+		Name:                 strOrDefault(params.Name),
+		Unit:                 strOrDefault(params.Unit),
+		Query:                "",
+		IncludeDeleted:       boolOrDefault(params.IncludeAll),
+		MinHolders:           uintOrDefault(params.MinHolders),
+		CreatedAfterRound:    uintOrDefault(params.CreatedAfterRound),
+		CreatedBeforeRound:   uintOrDefault(params.CreatedBeforeRound),
+		DestroyedAfterRound:  uintOrDefault(params.DestroyedAfterRound),
+		DestroyedBeforeRound: uintOrDefault(params.DestroyedBeforeRound),
+		Limit:                limit,
 	}
 
 	return query, nil
@@ -659,19 +882,24 @@ func (si *ServerImplementation) appParamsToApplicationQuery(params generated.Sea
 
 	var appGreaterThan uint64 = 0
 	if params.Next != nil {
-		agt, err := strconv.ParseUint(*params.Next, 10, 64)
+		agt, err := decodeUintNextToken(*params.Next)
 		if err != nil {
-			return idb.ApplicationQuery{}, fmt.Errorf("%s: %v", errUnableToParseNext, err)
+			return idb.ApplicationQuery{}, err
 		}
 		appGreaterThan = agt
 	}
 
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultApplicationsLimit, si.opts.MaxApplicationsLimit)
+	if err != nil {
+		return idb.ApplicationQuery{}, err
+	}
+
 	return idb.ApplicationQuery{
 		ApplicationID:            uintOrDefault(params.ApplicationId),
 		ApplicationIDGreaterThan: appGreaterThan,
 		Address:                  addr,
 		IncludeDeleted:           boolOrDefault(params.IncludeAll),
-		Limit:                    min(uintOrDefaultValue(params.Limit, si.opts.DefaultApplicationsLimit), si.opts.MaxApplicationsLimit),
+		Limit:                    limit,
 	}, nil
 }
 
@@ -683,8 +911,13 @@ func (si *ServerImplementation) transactionParamsToTransactionFilter(params gene
 	filter.MinRound = uintOrDefault(params.MinRound)
 	filter.AssetID = uintOrDefault(params.AssetId)
 	filter.ApplicationID = uintOrDefault(params.ApplicationId)
-	filter.Limit = min(uintOrDefaultValue(params.Limit, si.opts.DefaultTransactionsLimit), si.opts.MaxTransactionsLimit)
+	filter.Limit, err = resolveLimit(params.Limit, si.opts.DefaultTransactionsLimit, si.opts.MaxTransactionsLimit)
+	if err != nil {
+		return idb.TransactionFilter{}, err
+	}
 	filter.Round = params.Round
+	filter.MinFee = params.MinFee
+	filter.MaxFee = params.MaxFee
 
 	// String
 	filter.AddressRole, errorArr = decodeAddressRole((*string)(params.AddressRole), params.ExcludeCloseTo, errorArr)
@@ -693,9 +926,11 @@ func (si *ServerImplementation) transactionParamsToTransactionFilter(params gene
 	// Address
 	filter.Address, errorArr = decodeAddress(params.Address, "address", errorArr)
 	filter.Txid, errorArr = decodeDigest(params.Txid, "txid", errorArr)
+	filter.MultisigSubsigner, errorArr = decodeAddress(params.Subsigner, "subsigner", errorArr)
 
 	// Byte array
 	filter.NotePrefix, errorArr = decodeBase64Byte(params.NotePrefix, "note-prefix", errorArr)
+	filter.LogicSigHash, errorArr = decodeAddress(params.LogicsigHash, "logicsig-hash", errorArr)
 
 	// Time
 	if params.AfterTime != nil {
@@ -711,6 +946,8 @@ func (si *ServerImplementation) transactionParamsToTransactionFilter(params gene
 
 	// Boolean
 	filter.RekeyTo = params.RekeyTo
+	filter.ClosedAccount = params.ClosedAccount
+	filter.ClosedAssetHolding = params.ClosedAssetHolding
 
 	// filter Algos or Asset but not both.
 	if filter.AssetID != 0 || filter.TypeEnum == idb.TypeEnumAssetTransfer {
@@ -736,6 +973,7 @@ func (si *ServerImplementation) maxAccountsErrorToAccountsErrorResponse(maxErr i
 	addr := maxErr.Address.String()
 	max := uint64(si.opts.MaxAPIResourcesPerAccount)
 	extraData := map[string]interface{}{
+		"code":                  ErrCodeResultLimitExceeded,
 		"max-results":           max,
 		"address":               addr,
 		"total-assets-opted-in": maxErr.TotalAssets,
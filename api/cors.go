@@ -0,0 +1,23 @@
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// corsMiddleware builds the CORS middleware from ExtraOptions. With nothing
+// configured it falls back to middleware.CORS()'s defaults (any origin, the
+// standard method set), the same behavior this replaced.
+func corsMiddleware(options ExtraOptions) echo.MiddlewareFunc {
+	if len(options.CORSAllowOrigins) == 0 && len(options.CORSAllowMethods) == 0 &&
+		len(options.CORSAllowHeaders) == 0 && options.CORSMaxAge == 0 {
+		return middleware.CORS()
+	}
+
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: options.CORSAllowOrigins,
+		AllowMethods: options.CORSAllowMethods,
+		AllowHeaders: options.CORSAllowHeaders,
+		MaxAge:       options.CORSMaxAge,
+	})
+}
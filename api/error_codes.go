@@ -0,0 +1,41 @@
+package api
+
+// ErrorCode is a stable, machine-readable identifier included on every
+// generated.ErrorResponse (as Data["code"]) so that clients can branch on
+// failure type without parsing the free-text Message.
+type ErrorCode string
+
+// Error codes returned by the API. These are part of the public contract:
+// once added, a code should not be repurposed for a different failure mode.
+const (
+	// ErrCodeInvalidParameter is returned for malformed or mutually
+	// exclusive query parameters, decode failures, and similar 400s.
+	ErrCodeInvalidParameter ErrorCode = "INVALID_PARAMETER"
+	// ErrCodeResultLimitExceeded is returned when a search would return
+	// more rows than the server is configured to allow.
+	ErrCodeResultLimitExceeded ErrorCode = "RESULT_LIMIT_EXCEEDED"
+	// ErrCodeNotFound is returned when a singular lookup has no match.
+	ErrCodeNotFound ErrorCode = "NOT_FOUND"
+	// ErrCodeQueryTimeout is returned when a query did not complete
+	// within the server's configured timeout.
+	ErrCodeQueryTimeout ErrorCode = "QUERY_TIMEOUT"
+	// ErrCodeMigrationInProgress is returned when the database is
+	// unavailable because a migration is running or required.
+	ErrCodeMigrationInProgress ErrorCode = "MIGRATION_IN_PROGRESS"
+	// ErrCodeInternalError is returned for unexpected server-side
+	// failures that don't fall into one of the above categories.
+	ErrCodeInternalError ErrorCode = "INTERNAL_ERROR"
+)
+
+// errorData builds (or extends) the Data map attached to a
+// generated.ErrorResponse with a stable code and, when the failure is
+// attributable to a single request parameter, its name.
+func errorData(code ErrorCode, param string) *map[string]interface{} {
+	data := map[string]interface{}{
+		"code": code,
+	}
+	if param != "" {
+		data["parameter"] = param
+	}
+	return &data
+}
@@ -9,43 +9,57 @@ import (
 
 // constant error messages.
 const (
-	errInvalidRoundAndMinMax           = "cannot specify round and min-round/max-round"
-	errInvalidRoundMinMax              = "min-round must be less than max-round"
-	errUnableToParseAddress            = "unable to parse address"
-	errInvalidCreatorAddress           = "found an invalid creator address"
-	errUnableToParseBase64             = "unable to parse base64 data"
-	errUnableToParseDigest             = "unable to parse base32 digest data"
-	errUnableToParseNext               = "unable to parse next token"
-	errUnableToDecodeTransaction       = "unable to decode transaction bytes"
-	errFailedSearchingAccount          = "failed while searching for account"
-	errFailedSearchingAsset            = "failed while searching for asset"
-	errFailedSearchingAssetBalances    = "failed while searching for asset balances"
-	errFailedSearchingApplication      = "failed while searching for application"
-	errFailedSearchingBoxes            = "failed while searching for application boxes"
-	errFailedLookingUpHealth           = "failed while getting indexer health"
-	errNoApplicationsFound             = "no application found for application-id"
-	errNoBoxesFound                    = "no application boxes found"
-	errWrongAppidFound                 = "the wrong application-id was found, please contact us, this shouldn't happen"
-	errWrongBoxFound                   = "a box with an unexpected name was found, please contact us, this shouldn't happen"
-	ErrNoAccountsFound                 = "no accounts found for address"
-	errNoAssetsFound                   = "no assets found for asset-id"
-	errNoTransactionFound              = "no transaction found for transaction id"
-	errMultipleTransactions            = "multiple transactions found for this txid, please contact us, this shouldn't happen"
-	errMultipleAccounts                = "multiple accounts found for this address, please contact us, this shouldn't happen"
-	errMultipleAssets                  = "multiple assets found for this id, please contact us, this shouldn't happen"
-	errMultipleApplications            = "multiple applications found for this id, please contact us, this shouldn't happen"
-	errMultipleBoxes                   = "multiple application boxes found for this app id and box name, please contact us, this shouldn't happen"
-	errFailedLookingUpBoxes            = "failed while looking up application boxes"
-	errMultiAcctRewind                 = "multiple accounts rewind is not supported by this server"
-	errRewindingAccount                = "error while rewinding account"
-	errLookingUpBlockForRound          = "error while looking up block for round"
-	errTransactionSearch               = "error while searching for transaction"
-	errZeroAddressCloseRemainderToRole = "searching transactions by zero address with close address role is not supported"
-	errZeroAddressAssetSenderRole      = "searching transactions by zero address with asset sender role is not supported"
-	errZeroAddressAssetCloseToRole     = "searching transactions by zero address with asset close address role is not supported"
-	ErrResultLimitReached              = "Result limit exceeded"
-	errValueExceedingInt64             = "searching by round or application-id or asset-id or filter by value greater than 9223372036854775807 is not supported"
-	errTransactionsLimitReached        = "Max transactions limit exceeded. header-only flag should be enabled"
+	errInvalidRoundAndMinMax             = "cannot specify round and min-round/max-round"
+	errInvalidRoundMinMax                = "min-round must be less than max-round"
+	errInvalidFeeMinMax                  = "min-fee must be less than max-fee"
+	errUnableToParseAddress              = "unable to parse address"
+	errInvalidCreatorAddress             = "found an invalid creator address"
+	errUnableToParseBase64               = "unable to parse base64 data"
+	errUnableToParseDigest               = "unable to parse base32 digest data"
+	errUnableToParseNext                 = "unable to parse next token"
+	errUnsupportedNextTokenVersion       = "unsupported or missing next token format version"
+	errUnknownOrderBy                    = "unknown order-by value"
+	errUnableToDecodeTransaction         = "unable to decode transaction bytes"
+	errFailedSearchingAccount            = "failed while searching for account"
+	errFailedSearchingAsset              = "failed while searching for asset"
+	errFailedSearchingAssetBalances      = "failed while searching for asset balances"
+	errFailedSearchingApplication        = "failed while searching for application"
+	errFailedSearchingBoxes              = "failed while searching for application boxes"
+	errFailedLookingUpHealth             = "failed while getting indexer health"
+	errNoApplicationsFound               = "no application found for application-id"
+	errNoGlobalStateKeyFound             = "no global state value found for key"
+	errFailedToDisassembleProgram        = "failed to disassemble program"
+	errNoBoxesFound                      = "no application boxes found"
+	errWrongAppidFound                   = "the wrong application-id was found, please contact us, this shouldn't happen"
+	errWrongBoxFound                     = "a box with an unexpected name was found, please contact us, this shouldn't happen"
+	ErrNoAccountsFound                   = "no accounts found for address"
+	errNoAssetsFound                     = "no assets found for asset-id"
+	errNoTransactionFound                = "no transaction found for transaction id"
+	errMultipleTransactions              = "multiple transactions found for this txid, please contact us, this shouldn't happen"
+	errMultipleAccounts                  = "multiple accounts found for this address, please contact us, this shouldn't happen"
+	errMultipleAssets                    = "multiple assets found for this id, please contact us, this shouldn't happen"
+	errMultipleApplications              = "multiple applications found for this id, please contact us, this shouldn't happen"
+	errMultipleBoxes                     = "multiple application boxes found for this app id and box name, please contact us, this shouldn't happen"
+	errFailedLookingUpBoxes              = "failed while looking up application boxes"
+	errMultiAcctRewind                   = "multiple accounts rewind is not supported by this server"
+	errRewindingAccount                  = "error while rewinding account"
+	errLookingUpBlockForRound            = "error while looking up block for round"
+	errTransactionSearch                 = "error while searching for transaction"
+	errZeroAddressCloseRemainderToRole   = "searching transactions by zero address with close address role is not supported"
+	errZeroAddressAssetSenderRole        = "searching transactions by zero address with asset sender role is not supported"
+	errZeroAddressAssetCloseToRole       = "searching transactions by zero address with asset close address role is not supported"
+	ErrResultLimitReached                = "Result limit exceeded"
+	errValueExceedingInt64               = "searching by round or application-id or asset-id or filter by value greater than 9223372036854775807 is not supported"
+	errTransactionsLimitReached          = "Max transactions limit exceeded. header-only flag should be enabled"
+	errFailedSearchingParticipation      = "failed while searching for participation updates"
+	errFailedSearchingBalanceHistory     = "failed while searching for balance history"
+	errFailedSearchingRewards            = "failed while searching for rewards"
+	errOnlineStaleRoundsRequiresOnline   = "online-stale-rounds requires online=true"
+	errFailedSearchingOnlineStakeHistory = "failed while searching for online stake history"
+	errFailedSearchingAccountStateDelta  = "failed while searching for account state delta"
+	errTransfersRequireTxidXorGroupID    = "exactly one of txid or group-id is required"
+	errUnableToParseGroupID              = "unable to parse group-id"
+	errFailedSearchingTransfers          = "failed while searching for transfers"
 )
 
 var errUnknownAddressRole string
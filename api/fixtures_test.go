@@ -50,6 +50,9 @@ var fixtestServerOpts = ExtraOptions{
 	MaxBoxesLimit:     10000,
 	DefaultBoxesLimit: 1000,
 
+	MaxBlockHeadersLimit:     1000,
+	DefaultBlockHeadersLimit: 100,
+
 	DisabledMapConfig: MakeDisabledMapConfig(),
 }
 
@@ -39,6 +39,15 @@ type ServerInterface interface {
 	// (GET /v2/accounts/{account-id}/created-assets)
 	LookupAccountCreatedAssets(ctx echo.Context, accountId string, params LookupAccountCreatedAssetsParams) error
 
+	// (GET /v2/accounts/{account-id}/participation-updates)
+	LookupAccountParticipationUpdates(ctx echo.Context, accountId string, params LookupAccountParticipationUpdatesParams) error
+
+	LookupAccountBalanceHistory(ctx echo.Context, accountId string, params LookupAccountBalanceHistoryParams) error
+
+	LookupAccountRewards(ctx echo.Context, accountId string, params LookupAccountRewardsParams) error
+
+	SearchForAccountStateDelta(ctx echo.Context, params SearchForAccountStateDeltaParams) error
+
 	// (GET /v2/accounts/{account-id}/transactions)
 	LookupAccountTransactions(ctx echo.Context, accountId string, params LookupAccountTransactionsParams) error
 
@@ -53,6 +62,9 @@ type ServerInterface interface {
 	// Get box names for a given application.
 	// (GET /v2/applications/{application-id}/boxes)
 	SearchForApplicationBoxes(ctx echo.Context, applicationId uint64, params SearchForApplicationBoxesParams) error
+	// Get a single global state value for a given application by key.
+	// (GET /v2/applications/{application-id}/state)
+	LookupApplicationGlobalStateByKey(ctx echo.Context, applicationId uint64, params LookupApplicationGlobalStateByKeyParams) error
 
 	// (GET /v2/applications/{application-id}/logs)
 	LookupApplicationLogsByID(ctx echo.Context, applicationId uint64, params LookupApplicationLogsByIDParams) error
@@ -69,6 +81,18 @@ type ServerInterface interface {
 	// (GET /v2/assets/{asset-id}/transactions)
 	LookupAssetTransactions(ctx echo.Context, assetId uint64, params LookupAssetTransactionsParams) error
 
+	// (GET /v2/assets/{asset-id}/freezes)
+	LookupAssetFreezeTransactions(ctx echo.Context, assetId uint64, params LookupAssetFreezeTransactionsParams) error
+
+	// (GET /v2/assets/{asset-id}/clawbacks)
+	LookupAssetClawbackTransactions(ctx echo.Context, assetId uint64, params LookupAssetClawbackTransactionsParams) error
+
+	// (GET /v2/block-headers)
+	SearchForBlockHeaders(ctx echo.Context, params SearchForBlockHeadersParams) error
+
+	// (GET /v2/online-stake-history)
+	SearchForOnlineStakeHistory(ctx echo.Context, params SearchForOnlineStakeHistoryParams) error
+
 	// (GET /v2/blocks/{round-number})
 	LookupBlock(ctx echo.Context, roundNumber uint64, params LookupBlockParams) error
 
@@ -77,6 +101,9 @@ type ServerInterface interface {
 
 	// (GET /v2/transactions/{txid})
 	LookupTransaction(ctx echo.Context, txid string) error
+
+	// (GET /v2/transfers)
+	SearchForTransfers(ctx echo.Context, params SearchForTransfersParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -160,6 +187,55 @@ func (w *ServerInterfaceWrapper) SearchForAccounts(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
 	}
 
+	// ------------- Optional query parameter "order-by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order-by", ctx.QueryParams(), &params.OrderBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order-by: %s", err))
+	}
+
+	// ------------- Optional query parameter "order-desc" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order-desc", ctx.QueryParams(), &params.OrderDesc)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order-desc: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include-total", ctx.QueryParams(), &params.IncludeTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-total: %s", err))
+	}
+
+	// ------------- Optional query parameter "address-format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address-format", ctx.QueryParams(), &params.AddressFormat)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address-format: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-raw-address" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include-raw-address", ctx.QueryParams(), &params.IncludeRawAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-raw-address: %s", err))
+	}
+
+	// ------------- Optional query parameter "online" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "online", ctx.QueryParams(), &params.Online)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter online: %s", err))
+	}
+
+	// ------------- Optional query parameter "online-stale-rounds" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "online-stale-rounds", ctx.QueryParams(), &params.OnlineStaleRounds)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter online-stale-rounds: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.SearchForAccounts(ctx, params)
 	return err
@@ -199,6 +275,20 @@ func (w *ServerInterfaceWrapper) LookupAccountByID(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter exclude: %s", err))
 	}
 
+	// ------------- Optional query parameter "address-format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address-format", ctx.QueryParams(), &params.AddressFormat)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address-format: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-raw-address" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include-raw-address", ctx.QueryParams(), &params.IncludeRawAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-raw-address: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.LookupAccountByID(ctx, accountId, params)
 	return err
@@ -245,6 +335,13 @@ func (w *ServerInterfaceWrapper) LookupAccountAppLocalStates(ctx echo.Context) e
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
 	}
 
+	// ------------- Optional query parameter "round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.LookupAccountAppLocalStates(ctx, accountId, params)
 	return err
@@ -388,6 +485,141 @@ func (w *ServerInterfaceWrapper) LookupAccountCreatedAssets(ctx echo.Context) er
 	return err
 }
 
+// LookupAccountParticipationUpdates converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountParticipationUpdates(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account-id", runtime.ParamLocationPath, ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountParticipationUpdatesParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountParticipationUpdates(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountBalanceHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountBalanceHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account-id", runtime.ParamLocationPath, ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountBalanceHistoryParams
+	// ------------- Optional query parameter "after-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after-round", ctx.QueryParams(), &params.AfterRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before-round", ctx.QueryParams(), &params.BeforeRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountBalanceHistory(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountRewards converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountRewards(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account-id", runtime.ParamLocationPath, ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountRewardsParams
+	// ------------- Optional query parameter "after-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after-round", ctx.QueryParams(), &params.AfterRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before-round", ctx.QueryParams(), &params.BeforeRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-round: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountRewards(ctx, accountId, params)
+	return err
+}
+
+// SearchForAccountStateDelta converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForAccountStateDelta(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForAccountStateDeltaParams
+	// ------------- Required query parameter "min-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Required query parameter "max-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForAccountStateDelta(ctx, params)
+	return err
+}
+
 // LookupAccountTransactions converts echo context to params.
 func (w *ServerInterfaceWrapper) LookupAccountTransactions(ctx echo.Context) error {
 	var err error
@@ -436,6 +668,20 @@ func (w *ServerInterfaceWrapper) LookupAccountTransactions(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sig-type: %s", err))
 	}
 
+	// ------------- Optional query parameter "logicsig-hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "logicsig-hash", ctx.QueryParams(), &params.LogicsigHash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter logicsig-hash: %s", err))
+	}
+
+	// ------------- Optional query parameter "subsigner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subsigner", ctx.QueryParams(), &params.Subsigner)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subsigner: %s", err))
+	}
+
 	// ------------- Optional query parameter "txid" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
@@ -506,6 +752,20 @@ func (w *ServerInterfaceWrapper) LookupAccountTransactions(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rekey-to: %s", err))
 	}
 
+	// ------------- Optional query parameter "closed-account" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-account", ctx.QueryParams(), &params.ClosedAccount)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-account: %s", err))
+	}
+
+	// ------------- Optional query parameter "closed-asset-holding" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-asset-holding", ctx.QueryParams(), &params.ClosedAssetHolding)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-asset-holding: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.LookupAccountTransactions(ctx, accountId, params)
 	return err
@@ -577,6 +837,20 @@ func (w *ServerInterfaceWrapper) LookupApplicationByID(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
 	}
 
+	// ------------- Optional query parameter "round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include-source", ctx.QueryParams(), &params.IncludeSource)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-source: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.LookupApplicationByID(ctx, applicationId, params)
 	return err
@@ -639,6 +913,31 @@ func (w *ServerInterfaceWrapper) SearchForApplicationBoxes(ctx echo.Context) err
 	return err
 }
 
+// LookupApplicationGlobalStateByKey converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupApplicationGlobalStateByKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "application-id", runtime.ParamLocationPath, ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupApplicationGlobalStateByKeyParams
+	// ------------- Required query parameter "key" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "key", ctx.QueryParams(), &params.Key)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter key: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupApplicationGlobalStateByKey(ctx, applicationId, params)
+	return err
+}
+
 // LookupApplicationLogsByID converts echo context to params.
 func (w *ServerInterfaceWrapper) LookupApplicationLogsByID(ctx echo.Context) error {
 	var err error
@@ -754,6 +1053,41 @@ func (w *ServerInterfaceWrapper) SearchForAssets(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
 	}
 
+	// ------------- Optional query parameter "min-holders" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min-holders", ctx.QueryParams(), &params.MinHolders)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-holders: %s", err))
+	}
+
+	// ------------- Optional query parameter "created-after-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created-after-round", ctx.QueryParams(), &params.CreatedAfterRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created-after-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "created-before-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created-before-round", ctx.QueryParams(), &params.CreatedBeforeRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created-before-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "destroyed-after-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "destroyed-after-round", ctx.QueryParams(), &params.DestroyedAfterRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter destroyed-after-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "destroyed-before-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "destroyed-before-round", ctx.QueryParams(), &params.DestroyedBeforeRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter destroyed-before-round: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.SearchForAssets(ctx, params)
 	return err
@@ -885,6 +1219,20 @@ func (w *ServerInterfaceWrapper) LookupAssetTransactions(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sig-type: %s", err))
 	}
 
+	// ------------- Optional query parameter "logicsig-hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "logicsig-hash", ctx.QueryParams(), &params.LogicsigHash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter logicsig-hash: %s", err))
+	}
+
+	// ------------- Optional query parameter "subsigner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subsigner", ctx.QueryParams(), &params.Subsigner)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subsigner: %s", err))
+	}
+
 	// ------------- Optional query parameter "txid" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
@@ -969,11 +1317,216 @@ func (w *ServerInterfaceWrapper) LookupAssetTransactions(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rekey-to: %s", err))
 	}
 
+	// ------------- Optional query parameter "closed-account" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-account", ctx.QueryParams(), &params.ClosedAccount)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-account: %s", err))
+	}
+
+	// ------------- Optional query parameter "closed-asset-holding" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-asset-holding", ctx.QueryParams(), &params.ClosedAssetHolding)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-asset-holding: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.LookupAssetTransactions(ctx, assetId, params)
 	return err
 }
 
+// LookupAssetFreezeTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAssetFreezeTransactions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "asset-id", runtime.ParamLocationPath, ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAssetFreezeTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "address" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address", ctx.QueryParams(), &params.Address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	// ------------- Optional query parameter "address-role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address-role", ctx.QueryParams(), &params.AddressRole)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address-role: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAssetFreezeTransactions(ctx, assetId, params)
+	return err
+}
+
+// LookupAssetClawbackTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAssetClawbackTransactions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "asset-id", runtime.ParamLocationPath, ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAssetClawbackTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "address" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address", ctx.QueryParams(), &params.Address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	// ------------- Optional query parameter "address-role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "address-role", ctx.QueryParams(), &params.AddressRole)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address-role: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAssetClawbackTransactions(ctx, assetId, params)
+	return err
+}
+
+// SearchForBlockHeaders converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForBlockHeaders(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForBlockHeadersParams
+	// ------------- Optional query parameter "min-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForBlockHeaders(ctx, params)
+	return err
+}
+
+// SearchForOnlineStakeHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForOnlineStakeHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForOnlineStakeHistoryParams
+	// ------------- Optional query parameter "after-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after-round", ctx.QueryParams(), &params.AfterRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-round" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before-round", ctx.QueryParams(), &params.BeforeRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForOnlineStakeHistory(ctx, params)
+	return err
+}
+
 // LookupBlock converts echo context to params.
 func (w *ServerInterfaceWrapper) LookupBlock(ctx echo.Context) error {
 	var err error
@@ -1040,6 +1593,20 @@ func (w *ServerInterfaceWrapper) SearchForTransactions(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sig-type: %s", err))
 	}
 
+	// ------------- Optional query parameter "logicsig-hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "logicsig-hash", ctx.QueryParams(), &params.LogicsigHash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter logicsig-hash: %s", err))
+	}
+
+	// ------------- Optional query parameter "subsigner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subsigner", ctx.QueryParams(), &params.Subsigner)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subsigner: %s", err))
+	}
+
 	// ------------- Optional query parameter "txid" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
@@ -1131,6 +1698,20 @@ func (w *ServerInterfaceWrapper) SearchForTransactions(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rekey-to: %s", err))
 	}
 
+	// ------------- Optional query parameter "closed-account" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-account", ctx.QueryParams(), &params.ClosedAccount)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-account: %s", err))
+	}
+
+	// ------------- Optional query parameter "closed-asset-holding" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "closed-asset-holding", ctx.QueryParams(), &params.ClosedAssetHolding)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter closed-asset-holding: %s", err))
+	}
+
 	// ------------- Optional query parameter "application-id" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
@@ -1138,6 +1719,41 @@ func (w *ServerInterfaceWrapper) SearchForTransactions(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
 	}
 
+	// ------------- Optional query parameter "include-total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include-total", ctx.QueryParams(), &params.IncludeTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-total: %s", err))
+	}
+
+	// ------------- Optional query parameter "txid-format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "txid-format", ctx.QueryParams(), &params.TxidFormat)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid-format: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-fee" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min-fee", ctx.QueryParams(), &params.MinFee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-fee: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-fee" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max-fee", ctx.QueryParams(), &params.MaxFee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-fee: %s", err))
+	}
+
+	// ------------- Optional query parameter "omit-large-fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "omit-large-fields", ctx.QueryParams(), &params.OmitLargeFields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter omit-large-fields: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.SearchForTransactions(ctx, params)
 	return err
@@ -1159,6 +1775,31 @@ func (w *ServerInterfaceWrapper) LookupTransaction(ctx echo.Context) error {
 	return err
 }
 
+// SearchForTransfers converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForTransfers(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForTransfersParams
+	// ------------- Optional query parameter "txid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid: %s", err))
+	}
+
+	// ------------- Optional query parameter "group-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group-id", ctx.QueryParams(), &params.GroupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForTransfers(ctx, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1193,19 +1834,29 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/v2/accounts/:account-id/assets", wrapper.LookupAccountAssets, m...)
 	router.GET(baseURL+"/v2/accounts/:account-id/created-applications", wrapper.LookupAccountCreatedApplications, m...)
 	router.GET(baseURL+"/v2/accounts/:account-id/created-assets", wrapper.LookupAccountCreatedAssets, m...)
+	router.GET(baseURL+"/v2/accounts/:account-id/participation-updates", wrapper.LookupAccountParticipationUpdates, m...)
+	router.GET(baseURL+"/v2/accounts/:account-id/balance-history", wrapper.LookupAccountBalanceHistory, m...)
+	router.GET(baseURL+"/v2/accounts/:account-id/rewards", wrapper.LookupAccountRewards, m...)
+	router.GET(baseURL+"/v2/accounts/state-delta", wrapper.SearchForAccountStateDelta, m...)
 	router.GET(baseURL+"/v2/accounts/:account-id/transactions", wrapper.LookupAccountTransactions, m...)
 	router.GET(baseURL+"/v2/applications", wrapper.SearchForApplications, m...)
 	router.GET(baseURL+"/v2/applications/:application-id", wrapper.LookupApplicationByID, m...)
 	router.GET(baseURL+"/v2/applications/:application-id/box", wrapper.LookupApplicationBoxByIDAndName, m...)
 	router.GET(baseURL+"/v2/applications/:application-id/boxes", wrapper.SearchForApplicationBoxes, m...)
 	router.GET(baseURL+"/v2/applications/:application-id/logs", wrapper.LookupApplicationLogsByID, m...)
+	router.GET(baseURL+"/v2/applications/:application-id/state", wrapper.LookupApplicationGlobalStateByKey, m...)
 	router.GET(baseURL+"/v2/assets", wrapper.SearchForAssets, m...)
 	router.GET(baseURL+"/v2/assets/:asset-id", wrapper.LookupAssetByID, m...)
 	router.GET(baseURL+"/v2/assets/:asset-id/balances", wrapper.LookupAssetBalances, m...)
 	router.GET(baseURL+"/v2/assets/:asset-id/transactions", wrapper.LookupAssetTransactions, m...)
+	router.GET(baseURL+"/v2/assets/:asset-id/freezes", wrapper.LookupAssetFreezeTransactions, m...)
+	router.GET(baseURL+"/v2/assets/:asset-id/clawbacks", wrapper.LookupAssetClawbackTransactions, m...)
+	router.GET(baseURL+"/v2/block-headers", wrapper.SearchForBlockHeaders, m...)
+	router.GET(baseURL+"/v2/online-stake-history", wrapper.SearchForOnlineStakeHistory, m...)
 	router.GET(baseURL+"/v2/blocks/:round-number", wrapper.LookupBlock, m...)
 	router.GET(baseURL+"/v2/transactions", wrapper.SearchForTransactions, m...)
 	router.GET(baseURL+"/v2/transactions/:txid", wrapper.LookupTransaction, m...)
+	router.GET(baseURL+"/v2/transfers", wrapper.SearchForTransfers, m...)
 
 }
 
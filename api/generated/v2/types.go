@@ -76,6 +76,41 @@ const (
 	SearchForAccountsParamsExcludeNone           SearchForAccountsParamsExclude = "none"
 )
 
+// Defines values for SearchForAccountsParamsOrderBy.
+const (
+	SearchForAccountsParamsOrderByBalance    SearchForAccountsParamsOrderBy = "balance"
+	SearchForAccountsParamsOrderByCreatedAt  SearchForAccountsParamsOrderBy = "created-at"
+	SearchForAccountsParamsOrderByLastActive SearchForAccountsParamsOrderBy = "last-active"
+)
+
+// Defines values for SearchForAccountsParamsIncludeTotal.
+const (
+	SearchForAccountsParamsIncludeTotalEstimate SearchForAccountsParamsIncludeTotal = "estimate"
+)
+
+// Defines values for SearchForTransactionsParamsIncludeTotal.
+const (
+	SearchForTransactionsParamsIncludeTotalEstimate SearchForTransactionsParamsIncludeTotal = "estimate"
+)
+
+// Defines values for SearchForAccountsParamsAddressFormat.
+const (
+	SearchForAccountsParamsAddressFormatChecksum SearchForAccountsParamsAddressFormat = "checksum"
+	SearchForAccountsParamsAddressFormatHex      SearchForAccountsParamsAddressFormat = "hex"
+)
+
+// Defines values for LookupAccountByIDParamsAddressFormat.
+const (
+	LookupAccountByIDParamsAddressFormatChecksum LookupAccountByIDParamsAddressFormat = "checksum"
+	LookupAccountByIDParamsAddressFormatHex      LookupAccountByIDParamsAddressFormat = "hex"
+)
+
+// Defines values for SearchForTransactionsParamsTxidFormat.
+const (
+	SearchForTransactionsParamsTxidFormatChecksum SearchForTransactionsParamsTxidFormat = "checksum"
+	SearchForTransactionsParamsTxidFormatHex      SearchForTransactionsParamsTxidFormat = "hex"
+)
+
 // Defines values for LookupAccountByIDParamsExclude.
 const (
 	LookupAccountByIDParamsExcludeAll            LookupAccountByIDParamsExclude = "all"
@@ -129,6 +164,20 @@ const (
 	LookupAssetTransactionsParamsAddressRoleSender       LookupAssetTransactionsParamsAddressRole = "sender"
 )
 
+// Defines values for LookupAssetFreezeTransactionsParamsAddressRole.
+const (
+	LookupAssetFreezeTransactionsParamsAddressRoleFreezeTarget LookupAssetFreezeTransactionsParamsAddressRole = "freeze-target"
+	LookupAssetFreezeTransactionsParamsAddressRoleReceiver     LookupAssetFreezeTransactionsParamsAddressRole = "receiver"
+	LookupAssetFreezeTransactionsParamsAddressRoleSender       LookupAssetFreezeTransactionsParamsAddressRole = "sender"
+)
+
+// Defines values for LookupAssetClawbackTransactionsParamsAddressRole.
+const (
+	LookupAssetClawbackTransactionsParamsAddressRoleFreezeTarget LookupAssetClawbackTransactionsParamsAddressRole = "freeze-target"
+	LookupAssetClawbackTransactionsParamsAddressRoleReceiver     LookupAssetClawbackTransactionsParamsAddressRole = "receiver"
+	LookupAssetClawbackTransactionsParamsAddressRoleSender       LookupAssetClawbackTransactionsParamsAddressRole = "sender"
+)
+
 // Defines values for SearchForTransactionsParamsTxType.
 const (
 	Acfg   SearchForTransactionsParamsTxType = "acfg"
@@ -187,6 +236,9 @@ type Account struct {
 	// AuthAddr \[spend\] the address against which signing should be checked. If empty, the address of the current account is used. This field can be updated in any transaction by setting the RekeyTo field.
 	AuthAddr *string `json:"auth-addr,omitempty"`
 
+	// PublicKey The raw 32-byte public key backing address, base64 encoded. Only present when requested with include-raw-address, to ease integration with systems that store account keys as raw binary rather than the checksummed address string.
+	PublicKey *[]byte `json:"public-key,omitempty"`
+
 	// ClosedAtRound Round during which this account was most recently closed.
 	ClosedAtRound *uint64 `json:"closed-at-round,omitempty"`
 
@@ -206,6 +258,12 @@ type Account struct {
 	// Deleted Whether or not this account is currently closed.
 	Deleted *bool `json:"deleted,omitempty"`
 
+	// LastActiveRound Round during which this account most recently sent or received a transaction, including inner transactions.
+	LastActiveRound *uint64 `json:"last-active-round,omitempty"`
+
+	// LastHeartbeatRound Round during which this account most recently sent a key registration transaction, used as a proxy for online participation liveness since block proposer data isn't available. Only present if the account has ever sent one.
+	LastHeartbeatRound *uint64 `json:"last-heartbeat-round,omitempty"`
+
 	// Participation AccountParticipation describes the parameters used by this account in consensus protocol.
 	Participation *AccountParticipation `json:"participation,omitempty"`
 
@@ -251,6 +309,9 @@ type Account struct {
 
 	// TotalCreatedAssets The count of all assets (AssetParams objects) created by this account.
 	TotalCreatedAssets uint64 `json:"total-created-assets"`
+
+	// TotalTransactions The count of all transactions, including inner transactions, that have referenced this account.
+	TotalTransactions *uint64 `json:"total-transactions,omitempty"`
 }
 
 // AccountSigType Indicates what type of signature is used by this account, must be one of:
@@ -291,6 +352,12 @@ type AccountStateDelta struct {
 
 // Application Application index and its parameters
 type Application struct {
+	// CallCount Count of calls made to this application, including inner calls.
+	CallCount *uint64 `json:"call-count,omitempty"`
+
+	// ClosingTransaction Base32 ID of the transaction that deleted this application, included when include-all is set and the application is deleted.
+	ClosingTransaction *string `json:"closing-transaction,omitempty"`
+
 	// CreatedAtRound Round when this application was created.
 	CreatedAtRound *uint64 `json:"created-at-round,omitempty"`
 
@@ -305,6 +372,9 @@ type Application struct {
 
 	// Params Stores the global information associated with an application.
 	Params ApplicationParams `json:"params"`
+
+	// UniqueCallers Count of distinct addresses that have called this application.
+	UniqueCallers *uint64 `json:"unique-callers,omitempty"`
 }
 
 // ApplicationLocalState Stores local state associated with an application.
@@ -342,9 +412,15 @@ type ApplicationParams struct {
 	// ApprovalProgram \[approv\] approval program.
 	ApprovalProgram []byte `json:"approval-program"`
 
+	// ApprovalProgramDisassembly The disassembled TEAL source of the approval program, present when the request set include-source=disassembly.
+	ApprovalProgramDisassembly *string `json:"approval-program-disassembly,omitempty"`
+
 	// ClearStateProgram \[clearp\] approval program.
 	ClearStateProgram []byte `json:"clear-state-program"`
 
+	// ClearStateProgramDisassembly The disassembled TEAL source of the clear state program, present when the request set include-source=disassembly.
+	ClearStateProgramDisassembly *string `json:"clear-state-program-disassembly,omitempty"`
+
 	// Creator The address that created this application. This is the address where the parameters and global state for this application can be found.
 	Creator *string `json:"creator,omitempty"`
 
@@ -372,6 +448,9 @@ type ApplicationStateSchema struct {
 
 // Asset Specifies both the unique identifier and the parameters for an asset
 type Asset struct {
+	// ClosingTransaction Base32 ID of the transaction that destroyed this asset, included when include-all is set and the asset is deleted.
+	ClosingTransaction *string `json:"closing-transaction,omitempty"`
+
 	// CreatedAtRound Round during which this asset was created.
 	CreatedAtRound *uint64 `json:"created-at-round,omitempty"`
 
@@ -384,6 +463,9 @@ type Asset struct {
 	// Index unique asset identifier
 	Index uint64 `json:"index"`
 
+	// NumHolders Count of accounts currently opted in to this asset.
+	NumHolders *uint64 `json:"num-holders,omitempty"`
+
 	// Params AssetParams specifies the parameters for an asset.
 	//
 	// \[apar\] when part of an AssetConfig transaction.
@@ -696,6 +778,79 @@ type ParticipationUpdates struct {
 	ExpiredParticipationAccounts *[]string `json:"expired-participation-accounts,omitempty"`
 }
 
+// ApplicationKeyValueResponse defines model for ApplicationKeyValueResponse.
+type ApplicationKeyValueResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64       `json:"current-round"`
+	KeyValue     TealKeyValue `json:"key-value"`
+}
+
+// ParticipationUpdatesResponse defines model for ParticipationUpdatesResponse.
+type ParticipationUpdatesResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Rounds Rounds at which the account's participation keys were marked expired, most recent first.
+	Rounds []uint64 `json:"rounds"`
+}
+
+// BalanceHistoryEntry One round at which an account's balance was recorded.
+type BalanceHistoryEntry struct {
+	// Round Round at which the balance was recorded.
+	Round uint64 `json:"round"`
+
+	// Microalgos The account's balance, in microalgos, as of this round.
+	Microalgos uint64 `json:"microalgos"`
+}
+
+// BalanceHistoryResponse defines model for BalanceHistoryResponse.
+type BalanceHistoryResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Balances The account's balance history, oldest first.
+	Balances []BalanceHistoryEntry `json:"balances"`
+}
+
+// AccountStateDeltaEntry One account whose data was updated between min-round and max-round.
+type AccountStateDeltaEntry struct {
+	// Address The account's address.
+	Address string `json:"address"`
+
+	// BeforeMicroalgos The account's balance, in microalgos, as of min-round, or 0 if the account did not yet exist.
+	BeforeMicroalgos uint64 `json:"before-microalgos"`
+
+	// AfterMicroalgos The account's balance, in microalgos, as of max-round.
+	AfterMicroalgos uint64 `json:"after-microalgos"`
+}
+
+// AccountStateDeltaResponse defines model for AccountStateDeltaResponse.
+type AccountStateDeltaResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Deltas The accounts whose data was updated between min-round and max-round, ordered by address.
+	Deltas []AccountStateDeltaEntry `json:"deltas"`
+
+	// NextToken Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
+// RewardsSummaryResponse defines model for RewardsSummaryResponse.
+type RewardsSummaryResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// MinRound Earliest round within the requested range at which the account's rewards total was recorded.
+	MinRound uint64 `json:"min-round"`
+
+	// MaxRound Latest round within the requested range at which the account's rewards total was recorded.
+	MaxRound uint64 `json:"max-round"`
+
+	// RewardsEarned Rewards earned by the account between min-round and max-round, in microalgos.
+	RewardsEarned uint64 `json:"rewards-earned"`
+}
+
 // StateDelta Application state delta.
 type StateDelta = []EvalDeltaKeyValue
 
@@ -913,6 +1068,12 @@ type Transaction struct {
 	// Note \[note\] Free form data.
 	Note *[]byte `json:"note,omitempty"`
 
+	// NoteSize The size, in bytes, of the note field. Only present when note was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	NoteSize *uint64 `json:"note-size,omitempty"`
+
+	// NoteChecksum A base64 encoded byte array of the sha512/256 digest of the note field. Only present when note was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	NoteChecksum *[]byte `json:"note-checksum,omitempty"`
+
 	// PaymentTransaction Fields for a payment transaction.
 	//
 	// Definition:
@@ -985,9 +1146,21 @@ type TransactionApplication struct {
 	// ApprovalProgram \[apap\] Logic executed for every application transaction, except when on-completion is set to "clear". It can read and write global state for the application, as well as account-specific local state. Approval programs may reject the transaction.
 	ApprovalProgram *[]byte `json:"approval-program,omitempty"`
 
+	// ApprovalProgramSize The size, in bytes, of the approval-program field. Only present when approval-program was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	ApprovalProgramSize *uint64 `json:"approval-program-size,omitempty"`
+
+	// ApprovalProgramChecksum A base64 encoded byte array of the sha512/256 digest of the approval-program field. Only present when approval-program was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	ApprovalProgramChecksum *[]byte `json:"approval-program-checksum,omitempty"`
+
 	// ClearStateProgram \[apsu\] Logic executed for application transactions with on-completion set to "clear". It can read and write global state for the application, as well as account-specific local state. Clear state programs cannot reject the transaction.
 	ClearStateProgram *[]byte `json:"clear-state-program,omitempty"`
 
+	// ClearStateProgramSize The size, in bytes, of the clear-state-program field. Only present when clear-state-program was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	ClearStateProgramSize *uint64 `json:"clear-state-program-size,omitempty"`
+
+	// ClearStateProgramChecksum A base64 encoded byte array of the sha512/256 digest of the clear-state-program field. Only present when clear-state-program was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	ClearStateProgramChecksum *[]byte `json:"clear-state-program-checksum,omitempty"`
+
 	// ExtraProgramPages \[epp\] specifies the additional app program len requested in pages.
 	ExtraProgramPages *uint64 `json:"extra-program-pages,omitempty"`
 
@@ -1018,7 +1191,6 @@ type TransactionApplication struct {
 
 // TransactionAssetConfig Fields for asset allocation, re-configuration, and destruction.
 //
-//
 // A zero value for asset-id indicates asset creation.
 // A zero value for the params indicates asset destruction.
 //
@@ -1148,7 +1320,16 @@ type TransactionSignatureLogicsig struct {
 	Args *[]string `json:"args,omitempty"`
 
 	// Logic \[l\] Program signed by a signature or multi signature, or hashed to be the address of ana ccount. Base64 encoded TEAL program.
-	Logic []byte `json:"logic"`
+	//
+	// Omitted, in favor of LogicSize/LogicChecksum, when it exceeded the
+	// server's configured size threshold for an omit-large-fields request.
+	Logic []byte `json:"logic,omitempty"`
+
+	// LogicSize The size, in bytes, of the logic field. Only present when logic was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	LogicSize *uint64 `json:"logic-size,omitempty"`
+
+	// LogicChecksum A base64 encoded byte array of the sha512/256 digest of the logic field. Only present when logic was omitted because it exceeded the server's configured size threshold for an omit-large-fields request.
+	LogicChecksum *[]byte `json:"logic-checksum,omitempty"`
 
 	// MultisigSignature \[msig\] structure holding multiple subsignatures.
 	//
@@ -1303,6 +1484,9 @@ type AccountsResponse struct {
 
 	// NextToken Used for pagination, when making another request provide this token with the next parameter.
 	NextToken *string `json:"next-token,omitempty"`
+
+	// TotalEstimate A query planner estimate of the total number of results matching the filter, only present when include-total=estimate was requested.
+	TotalEstimate *uint64 `json:"total-estimate,omitempty"`
 }
 
 // ApplicationLocalStatesResponse defines model for ApplicationLocalStatesResponse.
@@ -1440,6 +1624,9 @@ type TransactionsResponse struct {
 	// NextToken Used for pagination, when making another request provide this token with the next parameter.
 	NextToken    *string       `json:"next-token,omitempty"`
 	Transactions []Transaction `json:"transactions"`
+
+	// TotalEstimate A query planner estimate of the total number of results matching the filter, only present when include-total=estimate was requested.
+	TotalEstimate *uint64 `json:"total-estimate,omitempty"`
 }
 
 // SearchForAccountsParams defines parameters for SearchForAccounts.
@@ -1473,11 +1660,41 @@ type SearchForAccountsParams struct {
 
 	// ApplicationId Application ID
 	ApplicationId *uint64 `form:"application-id,omitempty" json:"application-id,omitempty"`
+
+	// OrderBy Sort results by this field instead of address. Leaderboard and dashboard clients commonly sort by balance, created-at, or last-active rather than paging through every account in address order.
+	OrderBy *SearchForAccountsParamsOrderBy `form:"order-by,omitempty" json:"order-by,omitempty"`
+
+	// OrderDesc Sort order-by results in descending order instead of ascending.
+	OrderDesc *bool `form:"order-desc,omitempty" json:"order-desc,omitempty"`
+
+	// IncludeTotal Include an approximate total result count for the current filter, from the query planner rather than a COUNT(*). Omit for the default behavior of no count.
+	IncludeTotal *SearchForAccountsParamsIncludeTotal `form:"include-total,omitempty" json:"include-total,omitempty"`
+
+	// AddressFormat Encoding to use for the address field (and auth-addr, if present) of each returned account. Defaults to checksum, the standard 58-character base32 form. hex returns the 32-byte public key hex-encoded instead, to ease integration with systems that store account keys as raw binary.
+	AddressFormat *SearchForAccountsParamsAddressFormat `form:"address-format,omitempty" json:"address-format,omitempty"`
+
+	// IncludeRawAddress Include the raw 32-byte public key of each returned account, base64 encoded, as an additional field alongside address.
+	IncludeRawAddress *bool `form:"include-raw-address,omitempty" json:"include-raw-address,omitempty"`
+
+	// Online Restrict results to online accounts.
+	Online *bool `form:"online,omitempty" json:"online,omitempty"`
+
+	// OnlineStaleRounds Requires online=true. Restrict results to online accounts that have not sent a key registration transaction (their only available heartbeat signal, since block proposer data isn't imported) within this many rounds of the current round, for consensus-health monitoring of stale participation. Accounts that have never sent one are always included.
+	OnlineStaleRounds *uint64 `form:"online-stale-rounds,omitempty" json:"online-stale-rounds,omitempty"`
 }
 
 // SearchForAccountsParamsExclude defines parameters for SearchForAccounts.
 type SearchForAccountsParamsExclude string
 
+// SearchForAccountsParamsOrderBy defines parameters for SearchForAccounts.
+type SearchForAccountsParamsOrderBy string
+
+// SearchForAccountsParamsIncludeTotal defines parameters for SearchForAccounts.
+type SearchForAccountsParamsIncludeTotal string
+
+// SearchForAccountsParamsAddressFormat defines parameters for SearchForAccounts.
+type SearchForAccountsParamsAddressFormat string
+
 // LookupAccountByIDParams defines parameters for LookupAccountByID.
 type LookupAccountByIDParams struct {
 	// Round Include results for the specified round.
@@ -1488,11 +1705,20 @@ type LookupAccountByIDParams struct {
 
 	// Exclude Exclude additional items such as asset holdings, application local data stored for this account, asset parameters created by this account, and application parameters created by this account.
 	Exclude *[]LookupAccountByIDParamsExclude `form:"exclude,omitempty" json:"exclude,omitempty"`
+
+	// AddressFormat Encoding to use for the address field (and auth-addr, if present) of the returned account. Defaults to checksum, the standard 58-character base32 form. hex returns the 32-byte public key hex-encoded instead, to ease integration with systems that store account keys as raw binary.
+	AddressFormat *LookupAccountByIDParamsAddressFormat `form:"address-format,omitempty" json:"address-format,omitempty"`
+
+	// IncludeRawAddress Include the raw 32-byte public key of the returned account, base64 encoded, as an additional field alongside address.
+	IncludeRawAddress *bool `form:"include-raw-address,omitempty" json:"include-raw-address,omitempty"`
 }
 
 // LookupAccountByIDParamsExclude defines parameters for LookupAccountByID.
 type LookupAccountByIDParamsExclude string
 
+// LookupAccountByIDParamsAddressFormat defines parameters for LookupAccountByID.
+type LookupAccountByIDParamsAddressFormat string
+
 // LookupAccountAppLocalStatesParams defines parameters for LookupAccountAppLocalStates.
 type LookupAccountAppLocalStatesParams struct {
 	// ApplicationId Application ID
@@ -1506,6 +1732,9 @@ type LookupAccountAppLocalStatesParams struct {
 
 	// Next The next page of results. Use the next token provided by the previous results.
 	Next *string `form:"next,omitempty" json:"next,omitempty"`
+
+	// Round Reconstruct local state as of this round instead of the latest round, from application state history. Requires the indexer to have been run with --enable-app-state-history.
+	Round *uint64 `form:"round,omitempty" json:"round,omitempty"`
 }
 
 // LookupAccountAssetsParams defines parameters for LookupAccountAssets.
@@ -1553,6 +1782,48 @@ type LookupAccountCreatedAssetsParams struct {
 	Next *string `form:"next,omitempty" json:"next,omitempty"`
 }
 
+// LookupAccountParticipationUpdatesParams defines parameters for LookupAccountParticipationUpdates.
+type LookupAccountParticipationUpdatesParams struct {
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// LookupAccountBalanceHistoryParams defines parameters for LookupAccountBalanceHistory.
+type LookupAccountBalanceHistoryParams struct {
+	// AfterRound Return only balances recorded after the given round.
+	AfterRound *uint64 `form:"after-round,omitempty" json:"after-round,omitempty"`
+
+	// BeforeRound Return only balances recorded before the given round.
+	BeforeRound *uint64 `form:"before-round,omitempty" json:"before-round,omitempty"`
+
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchForAccountStateDeltaParams defines parameters for SearchForAccountStateDelta.
+type SearchForAccountStateDeltaParams struct {
+	// MinRound Include only changes after this round (exclusive).
+	MinRound uint64 `form:"min-round" json:"min-round"`
+
+	// MaxRound Include only changes up to and including this round.
+	MaxRound uint64 `form:"max-round" json:"max-round"`
+
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Next The next page of results. Use the next token provided by the previous results.
+	Next *string `form:"next,omitempty" json:"next,omitempty"`
+}
+
+// LookupAccountRewardsParams defines parameters for LookupAccountRewards.
+type LookupAccountRewardsParams struct {
+	// AfterRound Summarize rewards earned starting after the given round.
+	AfterRound *uint64 `form:"after-round,omitempty" json:"after-round,omitempty"`
+
+	// BeforeRound Summarize rewards earned up to and including the given round.
+	BeforeRound *uint64 `form:"before-round,omitempty" json:"before-round,omitempty"`
+}
+
 // LookupAccountTransactionsParams defines parameters for LookupAccountTransactions.
 type LookupAccountTransactionsParams struct {
 	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
@@ -1571,6 +1842,15 @@ type LookupAccountTransactionsParams struct {
 	// * lsig - LogicSig
 	SigType *LookupAccountTransactionsParamsSigType `form:"sig-type,omitempty" json:"sig-type,omitempty"`
 
+	// LogicsigHash Filters for transactions signed by a LogicSig program whose hash
+	// (its escrow account address) matches this value.
+	LogicsigHash *string `form:"logicsig-hash,omitempty" json:"logicsig-hash,omitempty"`
+
+	// Subsigner Filters for transactions authorized by a multisig (or delegated
+	// LogicSig multisig) that includes this address as a subsigner, whether or
+	// not it actually signed.
+	Subsigner *string `form:"subsigner,omitempty" json:"subsigner,omitempty"`
+
 	// Txid Lookup the specific transaction by ID.
 	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
 
@@ -1600,6 +1880,12 @@ type LookupAccountTransactionsParams struct {
 
 	// RekeyTo Include results which include the rekey-to field.
 	RekeyTo *bool `form:"rekey-to,omitempty" json:"rekey-to,omitempty"`
+
+	// ClosedAccount Include results for "pay" transactions which actually closed the sender's Algo balance to the close-remainder-to account.
+	ClosedAccount *bool `form:"closed-account,omitempty" json:"closed-account,omitempty"`
+
+	// ClosedAssetHolding Include results for "axfer" transactions which actually closed the sender's asset holding to the close-to account.
+	ClosedAssetHolding *bool `form:"closed-asset-holding,omitempty" json:"closed-asset-holding,omitempty"`
 }
 
 // LookupAccountTransactionsParamsTxType defines parameters for LookupAccountTransactions.
@@ -1630,6 +1916,12 @@ type SearchForApplicationsParams struct {
 type LookupApplicationByIDParams struct {
 	// IncludeAll Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
 	IncludeAll *bool `form:"include-all,omitempty" json:"include-all,omitempty"`
+
+	// Round Reconstruct global state as of this round instead of the latest round, from application state history. Requires the indexer to have been run with --enable-app-state-history.
+	Round *uint64 `form:"round,omitempty" json:"round,omitempty"`
+
+	// IncludeSource Set to "disassembly" to include the disassembled TEAL source of the approval and clear state programs in the response.
+	IncludeSource *string `form:"include-source,omitempty" json:"include-source,omitempty"`
 }
 
 // LookupApplicationBoxByIDAndNameParams defines parameters for LookupApplicationBoxByIDAndName.
@@ -1638,6 +1930,12 @@ type LookupApplicationBoxByIDAndNameParams struct {
 	Name string `form:"name" json:"name"`
 }
 
+// LookupApplicationGlobalStateByKeyParams defines parameters for LookupApplicationGlobalStateByKey.
+type LookupApplicationGlobalStateByKeyParams struct {
+	// Key The key for the value to retrieve, base64 encoded.
+	Key string `form:"key" json:"key"`
+}
+
 // SearchForApplicationBoxesParams defines parameters for SearchForApplicationBoxes.
 type SearchForApplicationBoxesParams struct {
 	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
@@ -1690,6 +1988,21 @@ type SearchForAssetsParams struct {
 
 	// AssetId Asset ID
 	AssetId *uint64 `form:"asset-id,omitempty" json:"asset-id,omitempty"`
+
+	// MinHolders Filter just assets with at least this many current opt-ins.
+	MinHolders *uint64 `form:"min-holders,omitempty" json:"min-holders,omitempty"`
+
+	// CreatedAfterRound Include only assets created after the given round.
+	CreatedAfterRound *uint64 `form:"created-after-round,omitempty" json:"created-after-round,omitempty"`
+
+	// CreatedBeforeRound Include only assets created before the given round.
+	CreatedBeforeRound *uint64 `form:"created-before-round,omitempty" json:"created-before-round,omitempty"`
+
+	// DestroyedAfterRound Include only assets destroyed after the given round. Implies include-all for the purpose of this filter.
+	DestroyedAfterRound *uint64 `form:"destroyed-after-round,omitempty" json:"destroyed-after-round,omitempty"`
+
+	// DestroyedBeforeRound Include only assets destroyed before the given round. Implies include-all for the purpose of this filter.
+	DestroyedBeforeRound *uint64 `form:"destroyed-before-round,omitempty" json:"destroyed-before-round,omitempty"`
 }
 
 // LookupAssetByIDParams defines parameters for LookupAssetByID.
@@ -1734,6 +2047,15 @@ type LookupAssetTransactionsParams struct {
 	// * lsig - LogicSig
 	SigType *LookupAssetTransactionsParamsSigType `form:"sig-type,omitempty" json:"sig-type,omitempty"`
 
+	// LogicsigHash Filters for transactions signed by a LogicSig program whose hash
+	// (its escrow account address) matches this value.
+	LogicsigHash *string `form:"logicsig-hash,omitempty" json:"logicsig-hash,omitempty"`
+
+	// Subsigner Filters for transactions authorized by a multisig (or delegated
+	// LogicSig multisig) that includes this address as a subsigner, whether or
+	// not it actually signed.
+	Subsigner *string `form:"subsigner,omitempty" json:"subsigner,omitempty"`
+
 	// Txid Lookup the specific transaction by ID.
 	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
 
@@ -1769,6 +2091,12 @@ type LookupAssetTransactionsParams struct {
 
 	// RekeyTo Include results which include the rekey-to field.
 	RekeyTo *bool `form:"rekey-to,omitempty" json:"rekey-to,omitempty"`
+
+	// ClosedAccount Include results for "pay" transactions which actually closed the sender's Algo balance to the close-remainder-to account.
+	ClosedAccount *bool `form:"closed-account,omitempty" json:"closed-account,omitempty"`
+
+	// ClosedAssetHolding Include results for "axfer" transactions which actually closed the sender's asset holding to the close-to account.
+	ClosedAssetHolding *bool `form:"closed-asset-holding,omitempty" json:"closed-asset-holding,omitempty"`
 }
 
 // LookupAssetTransactionsParamsTxType defines parameters for LookupAssetTransactions.
@@ -1780,12 +2108,117 @@ type LookupAssetTransactionsParamsSigType string
 // LookupAssetTransactionsParamsAddressRole defines parameters for LookupAssetTransactions.
 type LookupAssetTransactionsParamsAddressRole string
 
+// LookupAssetFreezeTransactionsParams defines parameters for LookupAssetFreezeTransactions.
+type LookupAssetFreezeTransactionsParams struct {
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Next The next page of results. Use the next token provided by the previous results.
+	Next *string `form:"next,omitempty" json:"next,omitempty"`
+
+	// MinRound Include results at or after the specified min-round.
+	MinRound *uint64 `form:"min-round,omitempty" json:"min-round,omitempty"`
+
+	// MaxRound Include results at or before the specified max-round.
+	MaxRound *uint64 `form:"max-round,omitempty" json:"max-round,omitempty"`
+
+	// Address Only include transactions with this address in one of the transaction fields.
+	Address *string `form:"address,omitempty" json:"address,omitempty"`
+
+	// AddressRole Combine with the address parameter to define what type of address to search for.
+	AddressRole *LookupAssetFreezeTransactionsParamsAddressRole `form:"address-role,omitempty" json:"address-role,omitempty"`
+}
+
+// LookupAssetFreezeTransactionsParamsAddressRole defines parameters for LookupAssetFreezeTransactions.
+type LookupAssetFreezeTransactionsParamsAddressRole string
+
+// LookupAssetClawbackTransactionsParams defines parameters for LookupAssetClawbackTransactions.
+type LookupAssetClawbackTransactionsParams struct {
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Next The next page of results. Use the next token provided by the previous results.
+	Next *string `form:"next,omitempty" json:"next,omitempty"`
+
+	// MinRound Include results at or after the specified min-round.
+	MinRound *uint64 `form:"min-round,omitempty" json:"min-round,omitempty"`
+
+	// MaxRound Include results at or before the specified max-round.
+	MaxRound *uint64 `form:"max-round,omitempty" json:"max-round,omitempty"`
+
+	// Address Only include transactions with this address in one of the transaction fields.
+	Address *string `form:"address,omitempty" json:"address,omitempty"`
+
+	// AddressRole Combine with the address parameter to define what type of address to search for.
+	AddressRole *LookupAssetClawbackTransactionsParamsAddressRole `form:"address-role,omitempty" json:"address-role,omitempty"`
+}
+
+// LookupAssetClawbackTransactionsParamsAddressRole defines parameters for LookupAssetClawbackTransactions.
+type LookupAssetClawbackTransactionsParamsAddressRole string
+
 // LookupBlockParams defines parameters for LookupBlock.
 type LookupBlockParams struct {
 	// HeaderOnly Header only flag. When this is set to true, returned block does not contain the transactions
 	HeaderOnly *bool `form:"header-only,omitempty" json:"header-only,omitempty"`
 }
 
+// SearchForBlockHeadersParams defines parameters for SearchForBlockHeaders.
+type SearchForBlockHeadersParams struct {
+	// MinRound Include results at or after the specified min-round.
+	MinRound *uint64 `form:"min-round,omitempty" json:"min-round,omitempty"`
+
+	// MaxRound Include results at or before the specified max-round.
+	MaxRound *uint64 `form:"max-round,omitempty" json:"max-round,omitempty"`
+
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Next The next page of results. Use the next token provided by the previous results.
+	Next *string `form:"next,omitempty" json:"next,omitempty"`
+}
+
+// BlockHeadersResponse defines model for BlockHeadersResponse.
+type BlockHeadersResponse struct {
+	// Blocks is a list of block headers with no transactions.
+	Blocks []Block `json:"blocks"`
+
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// NextToken used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
+// SearchForOnlineStakeHistoryParams defines parameters for SearchForOnlineStakeHistory.
+type SearchForOnlineStakeHistoryParams struct {
+	// AfterRound Return only rounds recorded after the given round.
+	AfterRound *uint64 `form:"after-round,omitempty" json:"after-round,omitempty"`
+
+	// BeforeRound Return only rounds recorded before the given round.
+	BeforeRound *uint64 `form:"before-round,omitempty" json:"before-round,omitempty"`
+
+	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// OnlineStakeHistoryEntry The total online stake recorded for one round.
+type OnlineStakeHistoryEntry struct {
+	// Round Round at which the total online stake was recorded.
+	Round uint64 `json:"round"`
+
+	// OnlineStake The network's total online stake, in microalgos, as of this round.
+	OnlineStake uint64 `json:"online-stake"`
+}
+
+// OnlineStakeHistoryResponse defines model for OnlineStakeHistoryResponse.
+type OnlineStakeHistoryResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// OnlineStakeHistory The network's total online stake history, oldest first.
+	OnlineStakeHistory []OnlineStakeHistoryEntry `json:"online-stake-history"`
+}
+
 // SearchForTransactionsParams defines parameters for SearchForTransactions.
 type SearchForTransactionsParams struct {
 	// Limit Maximum number of results to return. There could be additional pages even if the limit is not reached.
@@ -1804,6 +2237,15 @@ type SearchForTransactionsParams struct {
 	// * lsig - LogicSig
 	SigType *SearchForTransactionsParamsSigType `form:"sig-type,omitempty" json:"sig-type,omitempty"`
 
+	// LogicsigHash Filters for transactions signed by a LogicSig program whose hash
+	// (its escrow account address) matches this value.
+	LogicsigHash *string `form:"logicsig-hash,omitempty" json:"logicsig-hash,omitempty"`
+
+	// Subsigner Filters for transactions authorized by a multisig (or delegated
+	// LogicSig multisig) that includes this address as a subsigner, whether or
+	// not it actually signed.
+	Subsigner *string `form:"subsigner,omitempty" json:"subsigner,omitempty"`
+
 	// Txid Lookup the specific transaction by ID.
 	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
 
@@ -1843,8 +2285,29 @@ type SearchForTransactionsParams struct {
 	// RekeyTo Include results which include the rekey-to field.
 	RekeyTo *bool `form:"rekey-to,omitempty" json:"rekey-to,omitempty"`
 
+	// ClosedAccount Include results for "pay" transactions which actually closed the sender's Algo balance to the close-remainder-to account.
+	ClosedAccount *bool `form:"closed-account,omitempty" json:"closed-account,omitempty"`
+
+	// ClosedAssetHolding Include results for "axfer" transactions which actually closed the sender's asset holding to the close-to account.
+	ClosedAssetHolding *bool `form:"closed-asset-holding,omitempty" json:"closed-asset-holding,omitempty"`
+
 	// ApplicationId Application ID
 	ApplicationId *uint64 `form:"application-id,omitempty" json:"application-id,omitempty"`
+
+	// IncludeTotal Include an approximate total result count for the current filter, from the query planner rather than a COUNT(*). Omit for the default behavior of no count.
+	IncludeTotal *SearchForTransactionsParamsIncludeTotal `form:"include-total,omitempty" json:"include-total,omitempty"`
+
+	// TxidFormat Encoding to use for the id field of each returned transaction. Defaults to checksum, the standard base32 transaction ID form. hex returns the 32-byte transaction ID digest hex-encoded instead, to ease integration with systems that store transaction IDs as raw binary.
+	TxidFormat *SearchForTransactionsParamsTxidFormat `form:"txid-format,omitempty" json:"txid-format,omitempty"`
+
+	// MinFee Include results with a fee at or above this value, in microalgos. Only transactions imported since this filter was added have an indexed fee to match against, so it never matches older transactions.
+	MinFee *uint64 `form:"min-fee,omitempty" json:"min-fee,omitempty"`
+
+	// MaxFee Include results with a fee at or below this value, in microalgos. Only transactions imported since this filter was added have an indexed fee to match against, so it never matches older transactions.
+	MaxFee *uint64 `form:"max-fee,omitempty" json:"max-fee,omitempty"`
+
+	// OmitLargeFields Omit the note field and any approval-program, clear-state-program, or logicsig logic field whose encoded size exceeds the server's configured threshold, replacing each with its size and a sha512/256 checksum, to keep list responses lightweight. Use the lookup-by-txid endpoint to fetch the full transaction.
+	OmitLargeFields *bool `form:"omit-large-fields,omitempty" json:"omit-large-fields,omitempty"`
 }
 
 // SearchForTransactionsParamsTxType defines parameters for SearchForTransactions.
@@ -1855,3 +2318,54 @@ type SearchForTransactionsParamsSigType string
 
 // SearchForTransactionsParamsAddressRole defines parameters for SearchForTransactions.
 type SearchForTransactionsParamsAddressRole string
+
+// SearchForTransactionsParamsIncludeTotal defines parameters for SearchForTransactions.
+type SearchForTransactionsParamsIncludeTotal string
+
+// SearchForTransactionsParamsTxidFormat defines parameters for SearchForTransactions.
+type SearchForTransactionsParamsTxidFormat string
+
+// SearchForTransfersParams defines parameters for SearchForTransfers.
+type SearchForTransfersParams struct {
+	// Txid Lookup the transfer graph for the group containing this transaction.
+	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
+
+	// GroupId Lookup the transfer graph for this transaction group.
+	GroupId *string `form:"group-id,omitempty" json:"group-id,omitempty"`
+}
+
+// TransferEdge One transfer of Algos or an asset, produced by a root or inner transaction within a traced transfer graph.
+type TransferEdge struct {
+	// Txid The root transaction id this edge's transfer belongs to.
+	Txid string `json:"txid"`
+
+	Round uint64 `json:"round"`
+
+	// IntraRoundOffset Offset of the transaction that caused this edge within its round.
+	IntraRoundOffset uint64 `json:"intra-round-offset"`
+
+	// Type \[type\] Indicates what type of transaction this edge was produced by: pay or axfer.
+	Type string `json:"type"`
+
+	Sender string `json:"sender"`
+
+	Receiver string `json:"receiver"`
+
+	// AssetId ID of the asset transferred. Omitted for Algos.
+	AssetId *uint64 `json:"asset-id,omitempty"`
+
+	// Amount Amount transferred, in microalgos or asset base units.
+	Amount uint64 `json:"amount"`
+
+	// CloseTo Set if this edge represents a close-to transfer rather than the transaction's main transfer.
+	CloseTo *string `json:"close-to,omitempty"`
+}
+
+// TransferGraphResponse defines model for TransferGraphResponse.
+type TransferGraphResponse struct {
+	// CurrentRound Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Edges The transfer graph's edges, in the order their transactions appear in the group/block.
+	Edges []TransferEdge `json:"edges"`
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -19,6 +20,7 @@ import (
 	"github.com/algorand/indexer/accounting"
 	"github.com/algorand/indexer/api/generated/common"
 	"github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/api/middlewares"
 	"github.com/algorand/indexer/idb"
 	"github.com/algorand/indexer/util"
 	"github.com/algorand/indexer/version"
@@ -41,13 +43,38 @@ type ServerImplementation struct {
 
 	dataError func() error
 
-	timeout time.Duration
+	// timeout is read directly by tests; production code should prefer
+	// getTimeout/setTimeout, which stay consistent under concurrent
+	// ReloadRequest updates via timeoutOverride.
+	timeout         time.Duration
+	timeoutOverride atomic.Value // time.Duration, set once hot-reloaded
 
 	log *log.Logger
 
 	disabledParams *DisabledMap
 
 	opts ExtraOptions
+
+	// blockCache, txnCache, and assetCache hold hot, frequently-repeated
+	// lookups - the same recent blocks and transactions an explorer polls
+	// over and over. They're nil-safe no-ops when ResponseCacheSize is 0.
+	blockCache *lruCache
+	txnCache   *lruCache
+	assetCache *lruCache
+}
+
+// getTimeout returns the query timeout, reflecting the latest ReloadRequest
+// if one has been applied.
+func (si *ServerImplementation) getTimeout() time.Duration {
+	if v := si.timeoutOverride.Load(); v != nil {
+		return v.(time.Duration)
+	}
+	return si.timeout
+}
+
+// setTimeout hot-reloads the query timeout used by subsequent requests.
+func (si *ServerImplementation) setTimeout(d time.Duration) {
+	si.timeoutOverride.Store(d)
 }
 
 //////////////////////
@@ -83,6 +110,17 @@ func validateTransactionFilter(filter *idb.TransactionFilter) error {
 		errorArr = append(errorArr, errValueExceedingInt64)
 	}
 
+	// fee > math.MaxInt64
+	if (filter.MinFee != nil && *filter.MinFee > math.MaxInt64) ||
+		(filter.MaxFee != nil && *filter.MaxFee > math.MaxInt64) {
+		errorArr = append(errorArr, errValueExceedingInt64)
+	}
+
+	// If min/max fee are mixed up
+	if filter.MinFee != nil && filter.MaxFee != nil && *filter.MinFee > *filter.MaxFee {
+		errorArr = append(errorArr, errInvalidFeeMinMax)
+	}
+
 	// Round + min/max round
 	if filter.Round != nil && (filter.MaxRound != 0 || filter.MinRound != 0) {
 		errorArr = append(errorArr, errInvalidRoundAndMinMax)
@@ -121,7 +159,8 @@ func validateTransactionFilter(filter *idb.TransactionFilter) error {
 ////////////////////////////
 
 // MakeHealthCheck returns health check information about indexer and the IndexerDb being used.
-// Returns 200 if healthy.
+// Returns 200 if healthy. Pass ?detailed=true to also report the algod round and how far the
+// indexer is lagging behind it; this performs a live call to algod, so it's opt-in.
 // (GET /health)
 func (si *ServerImplementation) MakeHealthCheck(ctx echo.Context) error {
 	var err error
@@ -129,7 +168,7 @@ func (si *ServerImplementation) MakeHealthCheck(ctx echo.Context) error {
 	var health idb.Health
 
 	err = callWithTimeout(
-		ctx.Request().Context(), si.log, si.timeout, func(ctx context.Context) error {
+		ctx.Request().Context(), si.log, si.getTimeout(), func(ctx context.Context) error {
 			var err error
 			health, err = si.db.Health(ctx)
 			return err
@@ -148,9 +187,14 @@ func (si *ServerImplementation) MakeHealthCheck(ctx echo.Context) error {
 		}
 	}
 
+	data := health.Data
+	if ctx.QueryParam("detailed") == "true" {
+		data = si.addDetailedHealth(ctx.Request().Context(), data, health.Round, &errors)
+	}
+
 	return ctx.JSON(http.StatusOK, common.HealthCheck{
 		Version:     version.Version(),
-		Data:        health.Data,
+		Data:        data,
 		Round:       health.Round,
 		IsMigrating: health.IsMigrating,
 		DbAvailable: health.DBAvailable,
@@ -159,6 +203,32 @@ func (si *ServerImplementation) MakeHealthCheck(ctx echo.Context) error {
 	})
 }
 
+// addDetailedHealth augments data with the algod round and the indexer's lag behind it, fetched
+// live from si.opts.AlgodClient. A failure to reach algod is appended to errors rather than
+// failing the health check, since the database itself may still be healthy.
+func (si *ServerImplementation) addDetailedHealth(ctx context.Context, data *map[string]interface{}, dbRound uint64, errors *[]string) *map[string]interface{} {
+	if si.opts.AlgodClient == nil {
+		return data
+	}
+
+	status, err := si.opts.AlgodClient.Status().Do(ctx)
+	if err != nil {
+		*errors = append(*errors, fmt.Sprintf("algod status error: %s", err))
+		return data
+	}
+
+	if data == nil {
+		data = &map[string]interface{}{}
+	}
+	(*data)["algod-round"] = status.LastRound
+	if status.LastRound >= dbRound {
+		(*data)["round-lag"] = status.LastRound - dbRound
+	} else {
+		(*data)["round-lag"] = 0
+	}
+	return data
+}
+
 var errInvalidExcludeParameter = errors.New("invalid exclude argument")
 
 // set query options based on the value of the "exclude" parameter
@@ -202,7 +272,7 @@ func (si *ServerImplementation) LookupAccountByID(ctx echo.Context, accountID st
 
 	addr, decodeErrors := decodeAddress(&accountID, "account-id", make([]string, 0))
 	if len(decodeErrors) != 0 {
-		return badRequest(ctx, decodeErrors[0])
+		return badRequestParam(ctx, decodeErrors[0], "account-id")
 	}
 
 	options := idb.AccountQueryOptions{
@@ -244,6 +314,11 @@ func (si *ServerImplementation) LookupAccountByID(ctx echo.Context, accountID st
 		return indexerError(ctx, fmt.Errorf("%s: %s", errMultipleAccounts, accountID))
 	}
 
+	useHexAddress := params.AddressFormat != nil && *params.AddressFormat == generated.LookupAccountByIDParamsAddressFormatHex
+	if err := applyAddressFormat(accounts, useHexAddress, boolOrDefault(params.IncludeRawAddress)); err != nil {
+		return badRequestParam(ctx, err.Error(), "address-format")
+	}
+
 	return ctx.JSON(http.StatusOK, generated.AccountResponse{
 		CurrentRound: round,
 		Account:      accounts[0],
@@ -269,8 +344,9 @@ func (si *ServerImplementation) LookupAccountAppLocalStates(ctx echo.Context, ac
 	}
 	options, err := si.appParamsToApplicationQuery(search)
 	if err != nil {
-		return badRequest(ctx, err.Error())
+		return limitExceededOrBadRequest(ctx, err)
 	}
+	options.Round = params.Round
 
 	apps, round, err := si.fetchAppLocalStates(ctx.Request().Context(), options)
 	if err != nil {
@@ -279,7 +355,7 @@ func (si *ServerImplementation) LookupAccountAppLocalStates(ctx echo.Context, ac
 
 	var next *string
 	if len(apps) > 0 {
-		next = strPtr(strconv.FormatUint(apps[len(apps)-1].Id, 10))
+		next = strPtr(encodeUintNextToken(apps[len(apps)-1].Id))
 	}
 
 	out := generated.ApplicationLocalStatesResponse{
@@ -307,19 +383,24 @@ func (si *ServerImplementation) LookupAccountAssets(ctx echo.Context, accountID
 
 	var assetGreaterThan uint64 = 0
 	if params.Next != nil {
-		agt, err := strconv.ParseUint(*params.Next, 10, 64)
+		agt, err := decodeUintNextToken(*params.Next)
 		if err != nil {
-			return badRequest(ctx, fmt.Sprintf("%s: %v", errUnableToParseNext, err))
+			return badRequest(ctx, err.Error())
 		}
 		assetGreaterThan = agt
 	}
 
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultBalancesLimit, si.opts.MaxBalancesLimit)
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+
 	query := idb.AssetBalanceQuery{
 		Address:        addr,
 		AssetID:        uintOrDefault(params.AssetId),
 		AssetIDGT:      assetGreaterThan,
 		IncludeDeleted: boolOrDefault(params.IncludeAll),
-		Limit:          min(uintOrDefaultValue(params.Limit, si.opts.DefaultBalancesLimit), si.opts.MaxBalancesLimit),
+		Limit:          limit,
 	}
 
 	assets, round, err := si.fetchAssetHoldings(ctx.Request().Context(), query)
@@ -329,7 +410,7 @@ func (si *ServerImplementation) LookupAccountAssets(ctx echo.Context, accountID
 
 	var next *string
 	if len(assets) > 0 {
-		next = strPtr(strconv.FormatUint(assets[len(assets)-1].AssetId, 10))
+		next = strPtr(encodeUintNextToken(assets[len(assets)-1].AssetId))
 	}
 
 	return ctx.JSON(http.StatusOK, generated.AssetHoldingsResponse{
@@ -399,17 +480,58 @@ func (si *ServerImplementation) SearchForAccounts(ctx echo.Context, params gener
 		return badRequest(ctx, decodeErrors[0])
 	}
 
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultAccountsLimit, si.opts.MaxAccountsLimit)
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+
 	options := idb.AccountQueryOptions{
 		IncludeAssetHoldings: true,
 		IncludeAssetParams:   true,
 		IncludeAppLocalState: true,
 		IncludeAppParams:     true,
-		Limit:                min(uintOrDefaultValue(params.Limit, si.opts.DefaultAccountsLimit), si.opts.MaxAccountsLimit),
+		Limit:                limit,
 		HasAssetID:           uintOrDefault(params.AssetId),
 		HasAppID:             uintOrDefault(params.ApplicationId),
 		EqualToAuthAddr:      spendingAddr[:],
 		IncludeDeleted:       boolOrDefault(params.IncludeAll),
 		MaxResources:         si.opts.MaxAPIResourcesPerAccount,
+		OrderDescending:      boolOrDefault(params.OrderDesc),
+		OnlineOnly:           boolOrDefault(params.Online),
+	}
+
+	if params.OnlineStaleRounds != nil {
+		if !options.OnlineOnly {
+			return badRequestParam(ctx, errOnlineStaleRoundsRequiresOnline, "online-stale-rounds")
+		}
+		var health idb.Health
+		err := callWithTimeout(
+			ctx.Request().Context(), si.log, si.getTimeout(), func(ctx context.Context) error {
+				var err error
+				health, err = si.db.Health(ctx)
+				return err
+			})
+		if err != nil {
+			return indexerError(ctx, fmt.Errorf("%s: %w", errFailedLookingUpHealth, err))
+		}
+		var threshold uint64
+		if health.Round > *params.OnlineStaleRounds {
+			threshold = health.Round - *params.OnlineStaleRounds
+		}
+		options.MaxLastHeartbeatRound = &threshold
+	}
+
+	if params.OrderBy != nil {
+		switch *params.OrderBy {
+		case generated.SearchForAccountsParamsOrderByBalance:
+			options.OrderBy = idb.AccountOrderByBalance
+		case generated.SearchForAccountsParamsOrderByCreatedAt:
+			options.OrderBy = idb.AccountOrderByCreatedAt
+		case generated.SearchForAccountsParamsOrderByLastActive:
+			options.OrderBy = idb.AccountOrderByLastActive
+		default:
+			return badRequestParam(ctx, errUnknownOrderBy, "order-by")
+		}
 	}
 
 	if params.Exclude != nil {
@@ -433,11 +555,24 @@ func (si *ServerImplementation) SearchForAccounts(ctx echo.Context, params gener
 	}
 
 	if params.Next != nil {
-		addr, err := basics.UnmarshalChecksumAddress(*params.Next)
-		if err != nil {
-			return badRequest(ctx, errUnableToParseNext)
+		if options.OrderBy == idb.AccountOrderByAddress {
+			addr, err := basics.UnmarshalChecksumAddress(*params.Next)
+			if err != nil {
+				return badRequestParam(ctx, errUnableToParseNext, "next")
+			}
+			options.GreaterThanAddress = addr[:]
+		} else {
+			value, addrStr, err := decodeOrderedAccountNextToken(*params.Next)
+			if err != nil {
+				return badRequestParam(ctx, err.Error(), "next")
+			}
+			addr, err := basics.UnmarshalChecksumAddress(addrStr)
+			if err != nil {
+				return badRequestParam(ctx, errUnableToParseNext, "next")
+			}
+			options.GreaterThanOrderValue = &value
+			options.GreaterThanAddress = addr[:]
 		}
-		options.GreaterThanAddress = addr[:]
 	}
 
 	accounts, round, err := si.fetchAccounts(ctx.Request().Context(), options, params.Round)
@@ -451,7 +586,22 @@ func (si *ServerImplementation) SearchForAccounts(ctx echo.Context, params gener
 
 	var next *string
 	if len(accounts) > 0 {
-		next = strPtr(accounts[len(accounts)-1].Address)
+		last := accounts[len(accounts)-1]
+		switch options.OrderBy {
+		case idb.AccountOrderByBalance:
+			next = strPtr(encodeOrderedAccountNextToken(last.Amount, last.Address))
+		case idb.AccountOrderByCreatedAt:
+			next = strPtr(encodeOrderedAccountNextToken(uintOrDefault(last.CreatedAtRound), last.Address))
+		case idb.AccountOrderByLastActive:
+			next = strPtr(encodeOrderedAccountNextToken(uintOrDefault(last.LastActiveRound), last.Address))
+		default:
+			next = strPtr(last.Address)
+		}
+	}
+
+	useHexAddress := params.AddressFormat != nil && *params.AddressFormat == generated.SearchForAccountsParamsAddressFormatHex
+	if err := applyAddressFormat(accounts, useHexAddress, boolOrDefault(params.IncludeRawAddress)); err != nil {
+		return badRequestParam(ctx, err.Error(), "address-format")
 	}
 
 	response := generated.AccountsResponse{
@@ -460,9 +610,169 @@ func (si *ServerImplementation) SearchForAccounts(ctx echo.Context, params gener
 		Accounts:     accounts,
 	}
 
+	if params.IncludeTotal != nil && *params.IncludeTotal == generated.SearchForAccountsParamsIncludeTotalEstimate {
+		estimate, err := si.db.EstimateAccountsCount(ctx.Request().Context(), options)
+		if err != nil {
+			return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAccount, err))
+		}
+		response.TotalEstimate = &estimate
+	}
+
+	middlewares.SetResultCount(ctx, len(accounts))
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// LookupAccountParticipationUpdates returns the rounds at which an account's
+// participation keys were marked expired.
+// (GET /v2/accounts/{account-id}/participation-updates)
+func (si *ServerImplementation) LookupAccountParticipationUpdates(ctx echo.Context, accountID string, params generated.LookupAccountParticipationUpdatesParams) error {
+	if err := si.verifyHandler("LookupAccountParticipationUpdates", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	addr, errors := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errors) != 0 {
+		return badRequest(ctx, errors[0])
+	}
+
+	query := idb.ParticipationUpdateQuery{
+		Address: addr,
+		Limit:   uintOrDefault(params.Limit),
+	}
+
+	rounds, round, err := si.fetchParticipationUpdates(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingParticipation, err))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ParticipationUpdatesResponse{
+		CurrentRound: round,
+		Rounds:       rounds,
+	})
+}
+
+// LookupAccountBalanceHistory returns an account's balance history.
+// (GET /v2/accounts/{account-id}/balance-history)
+func (si *ServerImplementation) LookupAccountBalanceHistory(ctx echo.Context, accountID string, params generated.LookupAccountBalanceHistoryParams) error {
+	if err := si.verifyHandler("LookupAccountBalanceHistory", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	addr, errors := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errors) != 0 {
+		return badRequest(ctx, errors[0])
+	}
+
+	query := idb.BalanceHistoryQuery{
+		Address:     addr,
+		AfterRound:  uintOrDefault(params.AfterRound),
+		BeforeRound: uintOrDefault(params.BeforeRound),
+		Limit:       uintOrDefault(params.Limit),
+	}
+
+	balances, round, err := si.fetchBalanceHistory(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingBalanceHistory, err))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.BalanceHistoryResponse{
+		CurrentRound: round,
+		Balances:     balances,
+	})
+}
+
+// LookupAccountRewards returns a summary of rewards earned by an account
+// over a round range.
+// (GET /v2/accounts/{account-id}/rewards)
+func (si *ServerImplementation) LookupAccountRewards(ctx echo.Context, accountID string, params generated.LookupAccountRewardsParams) error {
+	if err := si.verifyHandler("LookupAccountRewards", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	addr, errors := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errors) != 0 {
+		return badRequest(ctx, errors[0])
+	}
+
+	query := idb.RewardsQuery{
+		Address:     addr,
+		AfterRound:  uintOrDefault(params.AfterRound),
+		BeforeRound: uintOrDefault(params.BeforeRound),
+	}
+
+	summary, round, err := si.fetchAccountRewards(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingRewards, err))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.RewardsSummaryResponse{
+		CurrentRound:  round,
+		MinRound:      summary.MinRound,
+		MaxRound:      summary.MaxRound,
+		RewardsEarned: summary.RewardsEarned,
+	})
+}
+
+// SearchForAccountStateDelta returns every account whose data was updated
+// between min-round and max-round, with its balance immediately before and
+// after the range (the two can be equal), so reconciliation systems can process incremental
+// changes instead of diffing full account snapshots themselves.
+// (GET /v2/accounts/state-delta)
+func (si *ServerImplementation) SearchForAccountStateDelta(ctx echo.Context, params generated.SearchForAccountStateDeltaParams) error {
+	if err := si.verifyHandler("SearchForAccountStateDelta", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	if params.MinRound > params.MaxRound {
+		return badRequest(ctx, errInvalidRoundMinMax)
+	}
+
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultAccountStateDeltaLimit, si.opts.MaxAccountStateDeltaLimit)
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+
+	query := idb.AccountStateDeltaQuery{
+		MinRound: params.MinRound,
+		MaxRound: params.MaxRound,
+		Limit:    limit,
+	}
+
+	if params.Next != nil {
+		addr, err := basics.UnmarshalChecksumAddress(*params.Next)
+		if err != nil {
+			return badRequestParam(ctx, errUnableToParseNext, "next")
+		}
+		query.GreaterThanAddress = addr[:]
+	}
+
+	deltas, round, err := si.fetchAccountStateDelta(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAccountStateDelta, err))
+	}
+
+	var next *string
+	if uint64(len(deltas)) == limit && limit != 0 {
+		next = strPtr(deltas[len(deltas)-1].Address)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.AccountStateDeltaResponse{
+		CurrentRound: round,
+		Deltas:       deltas,
+		NextToken:    next,
+	})
+}
+
+// fetchAccountRewards fetches the rewards summary for an account.
+func (si *ServerImplementation) fetchAccountRewards(ctx context.Context, params idb.RewardsQuery) (summary idb.RewardsSummary, round uint64, err error) {
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var innerErr error
+		summary, round, innerErr = si.db.AccountRewards(ctx, params)
+		return innerErr
+	})
+	return
+}
+
 // LookupAccountTransactions looks up transactions associated with a particular account.
 // (GET /v2/accounts/{account-id}/transactions)
 func (si *ServerImplementation) LookupAccountTransactions(ctx echo.Context, accountID string, params generated.LookupAccountTransactionsParams) error {
@@ -488,6 +798,8 @@ func (si *ServerImplementation) LookupAccountTransactions(ctx echo.Context, acco
 		Limit:               params.Limit,
 		Next:                params.Next,
 		NotePrefix:          params.NotePrefix,
+		LogicsigHash:        params.LogicsigHash,
+		Subsigner:           params.Subsigner,
 		TxType:              (*generated.SearchForTransactionsParamsTxType)(params.TxType),
 		SigType:             (*generated.SearchForTransactionsParamsSigType)(params.SigType),
 		Txid:                params.Txid,
@@ -499,6 +811,8 @@ func (si *ServerImplementation) LookupAccountTransactions(ctx echo.Context, acco
 		CurrencyGreaterThan: params.CurrencyGreaterThan,
 		CurrencyLessThan:    params.CurrencyLessThan,
 		RekeyTo:             params.RekeyTo,
+		ClosedAccount:       params.ClosedAccount,
+		ClosedAssetHolding:  params.ClosedAssetHolding,
 	}
 
 	return si.SearchForTransactions(ctx, searchParams)
@@ -515,7 +829,7 @@ func (si *ServerImplementation) SearchForApplications(ctx echo.Context, params g
 	}
 	options, err := si.appParamsToApplicationQuery(params)
 	if err != nil {
-		return badRequest(ctx, err.Error())
+		return limitExceededOrBadRequest(ctx, err)
 	}
 
 	apps, round, err := si.fetchApplications(ctx.Request().Context(), options)
@@ -525,7 +839,7 @@ func (si *ServerImplementation) SearchForApplications(ctx echo.Context, params g
 
 	var next *string
 	if len(apps) > 0 {
-		next = strPtr(strconv.FormatUint(apps[len(apps)-1].Id, 10))
+		next = strPtr(encodeUintNextToken(apps[len(apps)-1].Id))
 	}
 
 	out := generated.ApplicationsResponse{
@@ -533,6 +847,7 @@ func (si *ServerImplementation) SearchForApplications(ctx echo.Context, params g
 		CurrentRound: round,
 		NextToken:    next,
 	}
+	middlewares.SetResultCount(ctx, len(apps))
 	return ctx.JSON(http.StatusOK, out)
 }
 
@@ -549,6 +864,7 @@ func (si *ServerImplementation) LookupApplicationByID(ctx echo.Context, applicat
 		ApplicationID:  applicationID,
 		IncludeDeleted: boolOrDefault(params.IncludeAll),
 		Limit:          1,
+		Round:          params.Round,
 	}
 
 	apps, round, err := si.fetchApplications(ctx.Request().Context(), q)
@@ -564,12 +880,39 @@ func (si *ServerImplementation) LookupApplicationByID(ctx echo.Context, applicat
 		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleApplications, applicationID))
 	}
 
+	if params.IncludeSource != nil && *params.IncludeSource == "disassembly" {
+		if err := disassembleApplicationPrograms(&apps[0]); err != nil {
+			return indexerError(ctx, fmt.Errorf("%s: %w", errFailedToDisassembleProgram, err))
+		}
+	}
+
 	return ctx.JSON(http.StatusOK, generated.ApplicationResponse{
 		Application:  &(apps[0]),
 		CurrentRound: round,
 	})
 }
 
+// disassembleApplicationPrograms fills in the ApprovalProgramDisassembly and
+// ClearStateProgramDisassembly fields of app's params from its raw compiled
+// programs, for include-source=disassembly.
+func disassembleApplicationPrograms(app *generated.Application) error {
+	if len(app.Params.ApprovalProgram) > 0 {
+		text, err := logic.Disassemble(app.Params.ApprovalProgram)
+		if err != nil {
+			return err
+		}
+		app.Params.ApprovalProgramDisassembly = &text
+	}
+	if len(app.Params.ClearStateProgram) > 0 {
+		text, err := logic.Disassemble(app.Params.ClearStateProgram)
+		if err != nil {
+			return err
+		}
+		app.Params.ClearStateProgramDisassembly = &text
+	}
+	return nil
+}
+
 // LookupApplicationBoxByIDAndName returns the value of an application's box
 // (GET /v2/applications/{application-id}/box)
 func (si *ServerImplementation) LookupApplicationBoxByIDAndName(ctx echo.Context, applicationID uint64, params generated.LookupApplicationBoxByIDAndNameParams) error {
@@ -666,6 +1009,7 @@ func (si *ServerImplementation) SearchForApplicationBoxes(ctx echo.Context, appl
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// NOTE: as an application may have once existed, we DO NOT error when not finding the corresponding application ID
+			middlewares.SetResultCount(ctx, 0)
 			return ctx.JSON(http.StatusOK, happyResponse)
 		}
 		// sql.ErrNoRows is the only expected error condition
@@ -701,9 +1045,57 @@ func (si *ServerImplementation) SearchForApplicationBoxes(ctx echo.Context, appl
 	}
 	happyResponse.Boxes = descriptors
 
+	middlewares.SetResultCount(ctx, len(descriptors))
 	return ctx.JSON(http.StatusOK, happyResponse)
 }
 
+// LookupApplicationGlobalStateByKey returns a single global state value for
+// an application, identified by key, without transferring the rest of the
+// application's global state.
+// (GET /v2/applications/{application-id}/state)
+func (si *ServerImplementation) LookupApplicationGlobalStateByKey(ctx echo.Context, applicationID uint64, params generated.LookupApplicationGlobalStateByKeyParams) error {
+	if err := si.verifyHandler("LookupApplicationGlobalStateByKey", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if uint64(applicationID) > math.MaxInt64 {
+		return notFound(ctx, errValueExceedingInt64)
+	}
+
+	key, errors := decodeBase64Byte(&params.Key, "key", make([]string, 0))
+	if len(errors) != 0 {
+		return badRequest(ctx, errors[0])
+	}
+	encodedKey := base64.StdEncoding.EncodeToString(key)
+
+	q := idb.ApplicationQuery{
+		ApplicationID: applicationID,
+		Limit:         1,
+	}
+	apps, round, err := si.fetchApplications(ctx.Request().Context(), q)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingApplication, err))
+	}
+
+	if len(apps) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoApplicationsFound, applicationID))
+	}
+	if len(apps) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleApplications, applicationID))
+	}
+
+	if apps[0].Params.GlobalState != nil {
+		for _, kv := range *apps[0].Params.GlobalState {
+			if kv.Key == encodedKey {
+				return ctx.JSON(http.StatusOK, generated.ApplicationKeyValueResponse{
+					CurrentRound: round,
+					KeyValue:     kv,
+				})
+			}
+		}
+	}
+	return notFound(ctx, fmt.Sprintf("%s: %d", errNoGlobalStateKeyFound, applicationID))
+}
+
 // LookupApplicationLogsByID returns one application logs
 // (GET /v2/applications/{application-id}/logs)
 func (si *ServerImplementation) LookupApplicationLogsByID(ctx echo.Context, applicationID uint64, params generated.LookupApplicationLogsByIDParams) error {
@@ -727,7 +1119,7 @@ func (si *ServerImplementation) LookupApplicationLogsByID(ctx echo.Context, appl
 
 	filter, err := si.transactionParamsToTransactionFilter(searchParams)
 	if err != nil {
-		return badRequest(ctx, err.Error())
+		return limitExceededOrBadRequest(ctx, err)
 	}
 	filter.AddressRole = idb.AddressRoleSender
 	// If there is a match on an inner transaction, return the inner txn's logs
@@ -780,6 +1172,18 @@ func (si *ServerImplementation) LookupAssetByID(ctx echo.Context, assetID uint64
 		return notFound(ctx, errValueExceedingInt64)
 	}
 
+	// Asset params can change (reconfigure, destroy), so the cache key folds
+	// in the round the entry is valid as of; once the indexer moves past
+	// that round the old entry is simply never looked up again and ages out.
+	includeAll := boolOrDefault(params.IncludeAll)
+	var cacheKey string
+	if nextRound, err := si.db.GetNextRoundToAccount(); err == nil {
+		cacheKey = fmt.Sprintf("asset-%d-%v-%d", assetID, includeAll, nextRound)
+		if cached, ok := si.assetCache.get(cacheKey); ok {
+			return ctx.JSON(http.StatusOK, cached.(generated.AssetResponse))
+		}
+	}
+
 	search := generated.SearchForAssetsParams{
 		AssetId:    uint64Ptr(assetID),
 		Limit:      uint64Ptr(1),
@@ -803,10 +1207,15 @@ func (si *ServerImplementation) LookupAssetByID(ctx echo.Context, assetID uint64
 		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleAssets, assetID))
 	}
 
-	return ctx.JSON(http.StatusOK, generated.AssetResponse{
+	response := generated.AssetResponse{
 		Asset:        assets[0],
 		CurrentRound: round,
-	})
+	}
+	if cacheKey != "" {
+		si.assetCache.add(cacheKey, response)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
 }
 
 // LookupAssetBalances looks up balances for a particular asset
@@ -819,12 +1228,17 @@ func (si *ServerImplementation) LookupAssetBalances(ctx echo.Context, assetID ui
 		return notFound(ctx, errValueExceedingInt64)
 	}
 
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultBalancesLimit, si.opts.MaxBalancesLimit)
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+
 	query := idb.AssetBalanceQuery{
 		AssetID:        assetID,
 		AmountGT:       params.CurrencyGreaterThan,
 		AmountLT:       params.CurrencyLessThan,
 		IncludeDeleted: boolOrDefault(params.IncludeAll),
-		Limit:          min(uintOrDefaultValue(params.Limit, si.opts.DefaultBalancesLimit), si.opts.MaxBalancesLimit),
+		Limit:          limit,
 	}
 
 	if params.Next != nil {
@@ -867,6 +1281,8 @@ func (si *ServerImplementation) LookupAssetTransactions(ctx echo.Context, assetI
 		Limit:               params.Limit,
 		Next:                params.Next,
 		NotePrefix:          params.NotePrefix,
+		LogicsigHash:        params.LogicsigHash,
+		Subsigner:           params.Subsigner,
 		TxType:              (*generated.SearchForTransactionsParamsTxType)(params.TxType),
 		SigType:             (*generated.SearchForTransactionsParamsSigType)(params.SigType),
 		Txid:                params.Txid,
@@ -881,11 +1297,83 @@ func (si *ServerImplementation) LookupAssetTransactions(ctx echo.Context, assetI
 		AddressRole:         (*generated.SearchForTransactionsParamsAddressRole)(params.AddressRole),
 		ExcludeCloseTo:      params.ExcludeCloseTo,
 		RekeyTo:             params.RekeyTo,
+		ClosedAccount:       params.ClosedAccount,
+		ClosedAssetHolding:  params.ClosedAssetHolding,
 	}
 
 	return si.SearchForTransactions(ctx, searchParams)
 }
 
+// LookupAssetFreezeTransactions looks up freeze transactions associated with a particular asset
+// (GET /v2/assets/{asset-id}/freezes)
+func (si *ServerImplementation) LookupAssetFreezeTransactions(ctx echo.Context, assetID uint64, params generated.LookupAssetFreezeTransactionsParams) error {
+	if err := si.verifyHandler("LookupAssetFreezeTransactions", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if uint64(assetID) > math.MaxInt64 {
+		return notFound(ctx, errValueExceedingInt64)
+	}
+	txType := generated.Afrz
+	searchParams := generated.SearchForTransactionsParams{
+		AssetId:     uint64Ptr(assetID),
+		TxType:      &txType,
+		Limit:       params.Limit,
+		Next:        params.Next,
+		MinRound:    params.MinRound,
+		MaxRound:    params.MaxRound,
+		Address:     params.Address,
+		AddressRole: (*generated.SearchForTransactionsParamsAddressRole)(params.AddressRole),
+	}
+
+	return si.SearchForTransactions(ctx, searchParams)
+}
+
+// LookupAssetClawbackTransactions looks up clawback transactions (asset
+// transfers with an explicit AssetSender) associated with a particular asset
+// (GET /v2/assets/{asset-id}/clawbacks)
+func (si *ServerImplementation) LookupAssetClawbackTransactions(ctx echo.Context, assetID uint64, params generated.LookupAssetClawbackTransactionsParams) error {
+	if err := si.verifyHandler("LookupAssetClawbackTransactions", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if uint64(assetID) > math.MaxInt64 {
+		return notFound(ctx, errValueExceedingInt64)
+	}
+
+	txType := generated.Axfer
+	filter, err := si.transactionParamsToTransactionFilter(generated.SearchForTransactionsParams{
+		AssetId:     uint64Ptr(assetID),
+		TxType:      &txType,
+		Limit:       params.Limit,
+		Next:        params.Next,
+		MinRound:    params.MinRound,
+		MaxRound:    params.MaxRound,
+		Address:     params.Address,
+		AddressRole: (*generated.SearchForTransactionsParamsAddressRole)(params.AddressRole),
+	})
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+	filter.AssetSenderSet = boolPtr(true)
+
+	if err := validateTransactionFilter(&filter); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	txns, next, round, err := si.fetchTransactions(ctx.Request().Context(), filter)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errTransactionSearch, err))
+	}
+
+	response := generated.TransactionsResponse{
+		CurrentRound: round,
+		NextToken:    strPtr(next),
+		Transactions: txns,
+	}
+
+	middlewares.SetResultCount(ctx, len(txns))
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // SearchForAssets returns assets matching the provided parameters
 // (GET /v2/assets)
 func (si *ServerImplementation) SearchForAssets(ctx echo.Context, params generated.SearchForAssetsParams) error {
@@ -898,7 +1386,7 @@ func (si *ServerImplementation) SearchForAssets(ctx echo.Context, params generat
 
 	options, err := si.assetParamsToAssetQuery(params)
 	if err != nil {
-		return badRequest(ctx, err.Error())
+		return limitExceededOrBadRequest(ctx, err)
 	}
 
 	assets, round, err := si.fetchAssets(ctx.Request().Context(), options)
@@ -908,9 +1396,10 @@ func (si *ServerImplementation) SearchForAssets(ctx echo.Context, params generat
 
 	var next *string
 	if len(assets) > 0 {
-		next = strPtr(strconv.FormatUint(assets[len(assets)-1].Index, 10))
+		next = strPtr(encodeUintNextToken(assets[len(assets)-1].Index))
 	}
 
+	middlewares.SetResultCount(ctx, len(assets))
 	return ctx.JSON(http.StatusOK, generated.AssetsResponse{
 		CurrentRound: round,
 		NextToken:    next,
@@ -918,6 +1407,77 @@ func (si *ServerImplementation) SearchForAssets(ctx echo.Context, params generat
 	})
 }
 
+// SearchForBlockHeaders returns block headers for a round range in one call,
+// with no transactions, for explorers rendering recent-blocks lists.
+// (GET /v2/block-headers)
+func (si *ServerImplementation) SearchForBlockHeaders(ctx echo.Context, params generated.SearchForBlockHeadersParams) error {
+	if err := si.verifyHandler("SearchForBlockHeaders", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	minRound := uintOrDefault(params.MinRound)
+	if next := strOrDefault(params.Next); next != "" {
+		parsed, err := decodeUintNextToken(next)
+		if err != nil {
+			return badRequest(ctx, err.Error())
+		}
+		minRound = parsed
+	}
+
+	limit, err := resolveLimit(params.Limit, si.opts.DefaultBlockHeadersLimit, si.opts.MaxBlockHeadersLimit)
+	if err != nil {
+		return limitExceededOrBadRequest(ctx, err)
+	}
+
+	query := idb.BlockHeadersQuery{
+		MinRound: minRound,
+		MaxRound: uintOrDefault(params.MaxRound),
+		Limit:    limit,
+	}
+
+	blocks, round, err := si.fetchBlockHeaders(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errLookingUpBlockForRound, err))
+	}
+
+	var next *string
+	if uint64(len(blocks)) >= query.Limit && query.Limit != 0 {
+		next = strPtr(encodeUintNextToken(blocks[len(blocks)-1].Round + 1))
+	}
+
+	middlewares.SetResultCount(ctx, len(blocks))
+	return ctx.JSON(http.StatusOK, generated.BlockHeadersResponse{
+		CurrentRound: round,
+		Blocks:       blocks,
+		NextToken:    next,
+	})
+}
+
+// SearchForOnlineStakeHistory returns a time series of the network's total
+// online stake, for governance and network-health dashboards.
+// (GET /v2/online-stake-history)
+func (si *ServerImplementation) SearchForOnlineStakeHistory(ctx echo.Context, params generated.SearchForOnlineStakeHistoryParams) error {
+	if err := si.verifyHandler("SearchForOnlineStakeHistory", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	query := idb.OnlineStakeHistoryQuery{
+		AfterRound:  uintOrDefault(params.AfterRound),
+		BeforeRound: uintOrDefault(params.BeforeRound),
+		Limit:       uintOrDefault(params.Limit),
+	}
+
+	history, round, err := si.fetchOnlineStakeHistory(ctx.Request().Context(), query)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingOnlineStakeHistory, err))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.OnlineStakeHistoryResponse{
+		CurrentRound:       round,
+		OnlineStakeHistory: history,
+	})
+}
+
 // LookupBlock returns the block for a given round number
 // (GET /v2/blocks/{round-number})
 func (si *ServerImplementation) LookupBlock(ctx echo.Context, roundNumber uint64, params generated.LookupBlockParams) error {
@@ -928,8 +1488,16 @@ func (si *ServerImplementation) LookupBlock(ctx echo.Context, roundNumber uint64
 		return notFound(ctx, errValueExceedingInt64)
 	}
 
+	headerOnly := boolOrDefault(params.HeaderOnly)
+	cacheKey := fmt.Sprintf("block-%d-%v", roundNumber, headerOnly)
+	etag := fmt.Sprintf("block-%d", roundNumber)
+
+	if cached, ok := si.blockCache.get(cacheKey); ok {
+		return respondWithETag(ctx, etag, cached.(generated.BlockResponse))
+	}
+
 	options := idb.GetBlockOptions{
-		Transactions:         !(boolOrDefault(params.HeaderOnly)),
+		Transactions:         !headerOnly,
 		MaxTransactionsLimit: si.opts.MaxTransactionsLimit,
 	}
 
@@ -945,7 +1513,12 @@ func (si *ServerImplementation) LookupBlock(ctx echo.Context, roundNumber uint64
 		return indexerError(ctx, fmt.Errorf("%s '%d': %w", errLookingUpBlockForRound, roundNumber, err))
 	}
 
-	return ctx.JSON(http.StatusOK, generated.BlockResponse(blk))
+	response := generated.BlockResponse(blk)
+	si.blockCache.add(cacheKey, response)
+
+	// A confirmed block never changes, so its round number alone is a valid
+	// ETag and the response can be cached indefinitely.
+	return respondWithETag(ctx, etag, response)
 }
 
 // LookupTransaction searches for the requested transaction ID.
@@ -954,6 +1527,11 @@ func (si *ServerImplementation) LookupTransaction(ctx echo.Context, txid string)
 		return badRequest(ctx, err.Error())
 	}
 
+	etag := fmt.Sprintf("txn-%s", txid)
+	if cached, ok := si.txnCache.get(txid); ok {
+		return respondWithETag(ctx, etag, cached.(generated.TransactionResponse))
+	}
+
 	filter, err := si.transactionParamsToTransactionFilter(generated.SearchForTransactionsParams{
 		Txid: strPtr(txid),
 	})
@@ -984,8 +1562,11 @@ func (si *ServerImplementation) LookupTransaction(ctx echo.Context, txid string)
 		CurrentRound: round,
 		Transaction:  txns[0],
 	}
+	si.txnCache.add(txid, response)
 
-	return ctx.JSON(http.StatusOK, response)
+	// A confirmed transaction never changes, so its txid alone is a valid
+	// ETag and the response can be cached indefinitely.
+	return respondWithETag(ctx, etag, response)
 }
 
 // SearchForTransactions returns transactions matching the provided parameters
@@ -1002,7 +1583,7 @@ func (si *ServerImplementation) SearchForTransactions(ctx echo.Context, params g
 
 	filter, err := si.transactionParamsToTransactionFilter(params)
 	if err != nil {
-		return badRequest(ctx, err.Error())
+		return limitExceededOrBadRequest(ctx, err)
 	}
 
 	err = validateTransactionFilter(&filter)
@@ -1016,12 +1597,69 @@ func (si *ServerImplementation) SearchForTransactions(ctx echo.Context, params g
 		return indexerError(ctx, fmt.Errorf("%s: %w", errTransactionSearch, err))
 	}
 
+	useHexTxid := params.TxidFormat != nil && *params.TxidFormat == generated.SearchForTransactionsParamsTxidFormatHex
+	if err := applyTxidFormat(txns, useHexTxid); err != nil {
+		return badRequestParam(ctx, err.Error(), "txid-format")
+	}
+
+	if params.OmitLargeFields != nil && *params.OmitLargeFields && si.opts.MaxTxnFieldSize != 0 {
+		elideLargeFields(txns, si.opts.MaxTxnFieldSize)
+	}
+
 	response := generated.TransactionsResponse{
 		CurrentRound: round,
 		NextToken:    strPtr(next),
 		Transactions: txns,
 	}
 
+	if params.IncludeTotal != nil && *params.IncludeTotal == generated.SearchForTransactionsParamsIncludeTotalEstimate {
+		estimate, err := si.db.EstimateTransactionsCount(ctx.Request().Context(), filter)
+		if err != nil {
+			return indexerError(ctx, fmt.Errorf("%s: %w", errTransactionSearch, err))
+		}
+		response.TotalEstimate = &estimate
+	}
+
+	middlewares.SetResultCount(ctx, len(txns))
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// SearchForTransfers returns the transfer graph of an atomic transaction
+// group or a single transaction
+// (GET /v2/transfers)
+func (si *ServerImplementation) SearchForTransfers(ctx echo.Context, params generated.SearchForTransfersParams) error {
+	if err := si.verifyHandler("SearchForTransfers", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	if (params.Txid == nil) == (params.GroupId == nil) {
+		return badRequest(ctx, errTransfersRequireTxidXorGroupID)
+	}
+
+	errorArr := make([]string, 0)
+	var filter idb.TransactionFilter
+	if params.Txid != nil {
+		txid, ea := decodeDigest(params.Txid, "txid", errorArr)
+		errorArr = ea
+		filter.Txid = txid
+	} else {
+		groupID, ea := decodeGroupID(params.GroupId, errorArr)
+		errorArr = ea
+		filter.GroupID = groupID
+	}
+	if len(errorArr) > 0 {
+		return badRequest(ctx, strings.Join(errorArr, ", "))
+	}
+
+	txns, _, round, err := si.fetchTransactions(ctx.Request().Context(), filter)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingTransfers, err))
+	}
+
+	response := generated.TransferGraphResponse{
+		CurrentRound: round,
+		Edges:        transactionsToTransferEdges(txns),
+	}
 	return ctx.JSON(http.StatusOK, response)
 }
 
@@ -1031,8 +1669,14 @@ func (si *ServerImplementation) SearchForTransactions(ctx echo.Context, params g
 
 // return a 400
 func badRequest(ctx echo.Context, err string) error {
+	return badRequestParam(ctx, err, "")
+}
+
+// return a 400, naming the request parameter responsible for the failure.
+func badRequestParam(ctx echo.Context, err string, param string) error {
 	return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
 		Message: err,
+		Data:    errorData(ErrCodeInvalidParameter, param),
 	})
 }
 
@@ -1040,6 +1684,7 @@ func badRequest(ctx echo.Context, err string) error {
 func timeoutError(ctx echo.Context, err string) error {
 	return ctx.JSON(http.StatusServiceUnavailable, generated.ErrorResponse{
 		Message: err,
+		Data:    errorData(ErrCodeQueryTimeout, ""),
 	})
 }
 
@@ -1051,6 +1696,7 @@ func indexerError(ctx echo.Context, err error) error {
 
 	return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
 		Message: err.Error(),
+		Data:    errorData(ErrCodeInternalError, ""),
 	})
 }
 
@@ -1058,6 +1704,7 @@ func indexerError(ctx echo.Context, err error) error {
 func notFound(ctx echo.Context, err string) error {
 	return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
 		Message: err,
+		Data:    errorData(ErrCodeNotFound, ""),
 	})
 }
 
@@ -1070,7 +1717,7 @@ func (si *ServerImplementation) fetchApplications(ctx context.Context, params id
 	var round uint64
 	apps := make([]generated.Application, 0)
 	// TODO: add check
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var results <-chan idb.ApplicationRow
 		results, round = si.db.Applications(ctx, params)
 
@@ -1094,7 +1741,7 @@ func (si *ServerImplementation) fetchApplications(ctx context.Context, params id
 func (si *ServerImplementation) fetchApplicationBoxes(ctx context.Context, params idb.ApplicationBoxQuery) (appid generated.ApplicationId, boxes []generated.Box, round uint64, err error) {
 	boxes = make([]generated.Box, 0)
 
-	err = callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var results <-chan idb.ApplicationBoxRow
 		results, round = si.db.ApplicationBoxes(ctx, params)
 
@@ -1113,11 +1760,110 @@ func (si *ServerImplementation) fetchApplicationBoxes(ctx context.Context, param
 	return
 }
 
+// fetchParticipationUpdates fetches the rounds at which an account's
+// participation keys were marked expired.
+func (si *ServerImplementation) fetchParticipationUpdates(ctx context.Context, params idb.ParticipationUpdateQuery) (rounds []uint64, round uint64, err error) {
+	rounds = make([]uint64, 0)
+
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var results <-chan idb.ParticipationUpdateRow
+		results, round = si.db.ParticipationUpdates(ctx, params)
+
+		for result := range results {
+			if result.Error != nil {
+				return result.Error
+			}
+			rounds = append(rounds, result.Round)
+		}
+
+		return nil
+	})
+	return
+}
+
+// fetchBalanceHistory fetches an account's balance history.
+func (si *ServerImplementation) fetchBalanceHistory(ctx context.Context, params idb.BalanceHistoryQuery) (balances []generated.BalanceHistoryEntry, round uint64, err error) {
+	balances = make([]generated.BalanceHistoryEntry, 0)
+
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var results <-chan idb.BalanceHistoryRow
+		results, round = si.db.BalanceHistory(ctx, params)
+
+		for result := range results {
+			if result.Error != nil {
+				return result.Error
+			}
+			balances = append(balances, generated.BalanceHistoryEntry{
+				Round:      result.Round,
+				Microalgos: result.Microalgos,
+			})
+		}
+
+		return nil
+	})
+	return
+}
+
+// fetchOnlineStakeHistory fetches the network's total online stake history.
+func (si *ServerImplementation) fetchOnlineStakeHistory(ctx context.Context, params idb.OnlineStakeHistoryQuery) (history []generated.OnlineStakeHistoryEntry, round uint64, err error) {
+	history = make([]generated.OnlineStakeHistoryEntry, 0)
+
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var results <-chan idb.OnlineStakeHistoryRow
+		results, round = si.db.OnlineStakeHistory(ctx, params)
+
+		for result := range results {
+			if result.Error != nil {
+				return result.Error
+			}
+			history = append(history, generated.OnlineStakeHistoryEntry{
+				Round:       result.Round,
+				OnlineStake: result.OnlineStake,
+			})
+		}
+
+		return nil
+	})
+	return
+}
+
+// fetchAccountStateDelta fetches the accounts whose data was updated between
+// two rounds.
+func (si *ServerImplementation) fetchAccountStateDelta(ctx context.Context, params idb.AccountStateDeltaQuery) (deltas []generated.AccountStateDeltaEntry, round uint64, err error) {
+	deltas = make([]generated.AccountStateDeltaEntry, 0)
+
+	err = callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var results <-chan idb.AccountStateDeltaRow
+		results, round = si.db.AccountStateDelta(ctx, params)
+
+		for result := range results {
+			if result.Error != nil {
+				return result.Error
+			}
+
+			addr := basics.Address{}
+			if len(result.Address) != len(addr) {
+				return fmt.Errorf(errInvalidCreatorAddress)
+			}
+			copy(addr[:], result.Address[:])
+
+			deltas = append(deltas, generated.AccountStateDeltaEntry{
+				Address:          addr.String(),
+				BeforeMicroalgos: result.BeforeMicroalgos,
+				AfterMicroalgos:  result.AfterMicroalgos,
+			})
+		}
+
+		return nil
+	})
+	return
+}
+
 // fetchAppLocalStates fetches all generated.AppLocalState from a query
 func (si *ServerImplementation) fetchAppLocalStates(ctx context.Context, params idb.ApplicationQuery) ([]generated.ApplicationLocalState, uint64, error) {
 	var round uint64
 	als := make([]generated.ApplicationLocalState, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var results <-chan idb.AppLocalStateRow
 		results, round = si.db.AppLocalState(ctx, params)
 
@@ -1141,7 +1887,7 @@ func (si *ServerImplementation) fetchAppLocalStates(ctx context.Context, params
 func (si *ServerImplementation) fetchAssets(ctx context.Context, options idb.AssetsQuery) ([]generated.Asset, uint64 /*round*/, error) {
 	var round uint64
 	assets := make([]generated.Asset, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var assetchan <-chan idb.AssetRow
 		assetchan, round = si.db.Assets(ctx, options)
 		for row := range assetchan {
@@ -1159,10 +1905,12 @@ func (si *ServerImplementation) fetchAssets(ctx context.Context, options idb.Ass
 			copy(mdhash, row.Params.MetadataHash[:])
 
 			asset := generated.Asset{
-				Index:            row.AssetID,
-				CreatedAtRound:   row.CreatedRound,
-				DestroyedAtRound: row.ClosedRound,
-				Deleted:          row.Deleted,
+				Index:              row.AssetID,
+				CreatedAtRound:     row.CreatedRound,
+				DestroyedAtRound:   row.ClosedRound,
+				Deleted:            row.Deleted,
+				NumHolders:         uint64Ptr(row.NumHolders),
+				ClosingTransaction: strPtr(string(row.ClosingTxid)),
 				Params: generated.AssetParams{
 					Creator:       creator.String(),
 					Name:          strPtr(util.PrintableUTF8OrEmpty(row.Params.AssetName)),
@@ -1197,7 +1945,7 @@ func (si *ServerImplementation) fetchAssets(ctx context.Context, options idb.Ass
 func (si *ServerImplementation) fetchAssetBalances(ctx context.Context, options idb.AssetBalanceQuery) ([]generated.MiniAssetHolding, uint64 /*round*/, error) {
 	var round uint64
 	balances := make([]generated.MiniAssetHolding, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var assetbalchan <-chan idb.AssetBalanceRow
 		assetbalchan, round = si.db.AssetBalances(ctx, options)
 
@@ -1238,7 +1986,7 @@ func (si *ServerImplementation) fetchAssetBalances(ctx context.Context, options
 func (si *ServerImplementation) fetchAssetHoldings(ctx context.Context, options idb.AssetBalanceQuery) ([]generated.AssetHolding, uint64 /*round*/, error) {
 	var round uint64
 	balances := make([]generated.AssetHolding, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var assetbalchan <-chan idb.AssetBalanceRow
 		assetbalchan, round = si.db.AssetBalances(ctx, options)
 
@@ -1278,86 +2026,14 @@ func (si *ServerImplementation) fetchAssetHoldings(ctx context.Context, options
 // the method also loads the transactions into the returned block object.
 func (si *ServerImplementation) fetchBlock(ctx context.Context, round uint64, options idb.GetBlockOptions) (generated.Block, error) {
 	var ret generated.Block
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		blockHeader, transactions, err :=
 			si.db.GetBlock(ctx, round, options)
 		if err != nil {
 			return err
 		}
 
-		rewards := generated.BlockRewards{
-			FeeSink:                 blockHeader.FeeSink.String(),
-			RewardsCalculationRound: uint64(blockHeader.RewardsRecalculationRound),
-			RewardsLevel:            blockHeader.RewardsLevel,
-			RewardsPool:             blockHeader.RewardsPool.String(),
-			RewardsRate:             blockHeader.RewardsRate,
-			RewardsResidue:          blockHeader.RewardsResidue,
-		}
-
-		upgradeState := generated.BlockUpgradeState{
-			CurrentProtocol:        string(blockHeader.CurrentProtocol),
-			NextProtocol:           strPtr(string(blockHeader.NextProtocol)),
-			NextProtocolApprovals:  uint64Ptr(blockHeader.NextProtocolApprovals),
-			NextProtocolSwitchOn:   uint64Ptr(uint64(blockHeader.NextProtocolSwitchOn)),
-			NextProtocolVoteBefore: uint64Ptr(uint64(blockHeader.NextProtocolVoteBefore)),
-		}
-
-		upgradeVote := generated.BlockUpgradeVote{
-			UpgradeApprove: boolPtr(blockHeader.UpgradeApprove),
-			UpgradeDelay:   uint64Ptr(uint64(blockHeader.UpgradeDelay)),
-			UpgradePropose: strPtr(string(blockHeader.UpgradePropose)),
-		}
-
-		var partUpdates *generated.ParticipationUpdates
-		if len(blockHeader.ExpiredParticipationAccounts) > 0 {
-			addrs := make([]string, len(blockHeader.ExpiredParticipationAccounts))
-			for i := 0; i < len(addrs); i++ {
-				addrs[i] = blockHeader.ExpiredParticipationAccounts[i].String()
-			}
-			partUpdates = &generated.ParticipationUpdates{
-				ExpiredParticipationAccounts: strArrayPtr(addrs),
-			}
-		} else {
-			partUpdates = nil
-		}
-
-		// order these so they're deterministic
-		orderedTrackingTypes := make([]sdk.StateProofType, len(blockHeader.StateProofTracking))
-		trackingArray := make([]generated.StateProofTracking, len(blockHeader.StateProofTracking))
-		elems := 0
-		for key := range blockHeader.StateProofTracking {
-			orderedTrackingTypes[elems] = key
-			elems++
-		}
-		sort.Slice(orderedTrackingTypes, func(i, j int) bool { return orderedTrackingTypes[i] < orderedTrackingTypes[j] })
-		for i := 0; i < len(orderedTrackingTypes); i++ {
-			stpfTracking := blockHeader.StateProofTracking[orderedTrackingTypes[i]]
-			thing1 := generated.StateProofTracking{
-				NextRound:         uint64Ptr(uint64(stpfTracking.StateProofNextRound)),
-				Type:              uint64Ptr(uint64(orderedTrackingTypes[i])),
-				VotersCommitment:  byteSliceOmitZeroPtr(stpfTracking.StateProofVotersCommitment),
-				OnlineTotalWeight: uint64Ptr(uint64(stpfTracking.StateProofOnlineTotalWeight)),
-			}
-			trackingArray[orderedTrackingTypes[i]] = thing1
-		}
-
-		ret = generated.Block{
-			GenesisHash:            blockHeader.GenesisHash[:],
-			GenesisId:              blockHeader.GenesisID,
-			ParticipationUpdates:   partUpdates,
-			PreviousBlockHash:      blockHeader.Branch[:],
-			Rewards:                &rewards,
-			Round:                  uint64(blockHeader.Round),
-			Seed:                   blockHeader.Seed[:],
-			StateProofTracking:     &trackingArray,
-			Timestamp:              uint64(blockHeader.TimeStamp),
-			Transactions:           nil,
-			TransactionsRoot:       blockHeader.TxnCommitments.NativeSha512_256Commitment[:],
-			TransactionsRootSha256: blockHeader.TxnCommitments.Sha256Commitment[:],
-			TxnCounter:             uint64Ptr(blockHeader.TxnCounter),
-			UpgradeState:           &upgradeState,
-			UpgradeVote:            &upgradeVote,
-		}
+		ret = blockHeaderToBlock(blockHeader)
 
 		results := make([]generated.Transaction, 0)
 		for _, txrow := range transactions {
@@ -1383,12 +2059,110 @@ func (si *ServerImplementation) fetchBlock(ctx context.Context, round uint64, op
 	return ret, nil
 }
 
+// blockHeaderToBlock converts a block header into a generated.Block with no
+// transactions attached, shared by fetchBlock and fetchBlockHeaders.
+func blockHeaderToBlock(blockHeader sdk.BlockHeader) generated.Block {
+	rewards := generated.BlockRewards{
+		FeeSink:                 blockHeader.FeeSink.String(),
+		RewardsCalculationRound: uint64(blockHeader.RewardsRecalculationRound),
+		RewardsLevel:            blockHeader.RewardsLevel,
+		RewardsPool:             blockHeader.RewardsPool.String(),
+		RewardsRate:             blockHeader.RewardsRate,
+		RewardsResidue:          blockHeader.RewardsResidue,
+	}
+
+	upgradeState := generated.BlockUpgradeState{
+		CurrentProtocol:        string(blockHeader.CurrentProtocol),
+		NextProtocol:           strPtr(string(blockHeader.NextProtocol)),
+		NextProtocolApprovals:  uint64Ptr(blockHeader.NextProtocolApprovals),
+		NextProtocolSwitchOn:   uint64Ptr(uint64(blockHeader.NextProtocolSwitchOn)),
+		NextProtocolVoteBefore: uint64Ptr(uint64(blockHeader.NextProtocolVoteBefore)),
+	}
+
+	upgradeVote := generated.BlockUpgradeVote{
+		UpgradeApprove: boolPtr(blockHeader.UpgradeApprove),
+		UpgradeDelay:   uint64Ptr(uint64(blockHeader.UpgradeDelay)),
+		UpgradePropose: strPtr(string(blockHeader.UpgradePropose)),
+	}
+
+	var partUpdates *generated.ParticipationUpdates
+	if len(blockHeader.ExpiredParticipationAccounts) > 0 {
+		addrs := make([]string, len(blockHeader.ExpiredParticipationAccounts))
+		for i := 0; i < len(addrs); i++ {
+			addrs[i] = blockHeader.ExpiredParticipationAccounts[i].String()
+		}
+		partUpdates = &generated.ParticipationUpdates{
+			ExpiredParticipationAccounts: strArrayPtr(addrs),
+		}
+	} else {
+		partUpdates = nil
+	}
+
+	// order these so they're deterministic
+	orderedTrackingTypes := make([]sdk.StateProofType, len(blockHeader.StateProofTracking))
+	trackingArray := make([]generated.StateProofTracking, len(blockHeader.StateProofTracking))
+	elems := 0
+	for key := range blockHeader.StateProofTracking {
+		orderedTrackingTypes[elems] = key
+		elems++
+	}
+	sort.Slice(orderedTrackingTypes, func(i, j int) bool { return orderedTrackingTypes[i] < orderedTrackingTypes[j] })
+	for i := 0; i < len(orderedTrackingTypes); i++ {
+		stpfTracking := blockHeader.StateProofTracking[orderedTrackingTypes[i]]
+		thing1 := generated.StateProofTracking{
+			NextRound:         uint64Ptr(uint64(stpfTracking.StateProofNextRound)),
+			Type:              uint64Ptr(uint64(orderedTrackingTypes[i])),
+			VotersCommitment:  byteSliceOmitZeroPtr(stpfTracking.StateProofVotersCommitment),
+			OnlineTotalWeight: uint64Ptr(uint64(stpfTracking.StateProofOnlineTotalWeight)),
+		}
+		trackingArray[orderedTrackingTypes[i]] = thing1
+	}
+
+	return generated.Block{
+		GenesisHash:            blockHeader.GenesisHash[:],
+		GenesisId:              blockHeader.GenesisID,
+		ParticipationUpdates:   partUpdates,
+		PreviousBlockHash:      blockHeader.Branch[:],
+		Rewards:                &rewards,
+		Round:                  uint64(blockHeader.Round),
+		Seed:                   blockHeader.Seed[:],
+		StateProofTracking:     &trackingArray,
+		Timestamp:              uint64(blockHeader.TimeStamp),
+		Transactions:           nil,
+		TransactionsRoot:       blockHeader.TxnCommitments.NativeSha512_256Commitment[:],
+		TransactionsRootSha256: blockHeader.TxnCommitments.Sha256Commitment[:],
+		TxnCounter:             uint64Ptr(blockHeader.TxnCounter),
+		UpgradeState:           &upgradeState,
+		UpgradeVote:            &upgradeVote,
+	}
+}
+
+// fetchBlockHeaders fetches a range of block headers (no transactions), for
+// explorers rendering recent-blocks lists without a GetBlock call per block.
+func (si *ServerImplementation) fetchBlockHeaders(ctx context.Context, params idb.BlockHeadersQuery) ([]generated.Block, uint64, error) {
+	blocks := make([]generated.Block, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
+		var rows <-chan idb.BlockHeaderRow
+		rows, round = si.db.GetBlockHeaders(ctx, params)
+
+		for row := range rows {
+			if row.Error != nil {
+				return row.Error
+			}
+			blocks = append(blocks, blockHeaderToBlock(row.Header))
+		}
+		return nil
+	})
+	return blocks, round, err
+}
+
 // fetchAccounts queries for accounts and converts them into generated.Account
 // objects, optionally rewinding their value back to a particular round.
 func (si *ServerImplementation) fetchAccounts(ctx context.Context, options idb.AccountQueryOptions, atRound *uint64) ([]generated.Account, uint64 /*round*/, error) {
 	var round uint64
 	accounts := make([]generated.Account, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var accountchan <-chan idb.AccountRow
 		accountchan, round = si.db.GetAccounts(ctx, options)
 
@@ -1444,7 +2218,7 @@ func (si *ServerImplementation) fetchTransactions(ctx context.Context, filter id
 	var round uint64
 	var nextToken string
 	results := make([]generated.Transaction, 0)
-	err := callWithTimeout(ctx, si.log, si.timeout, func(ctx context.Context) error {
+	err := callWithTimeout(ctx, si.log, si.getTimeout(), func(ctx context.Context) error {
 		var txchan <-chan idb.TxnRow
 		txchan, round = si.db.Transactions(ctx, filter)
 
@@ -1491,13 +2265,126 @@ func (si *ServerImplementation) fetchTransactions(ctx context.Context, filter id
 	return results, nextToken, round, nil
 }
 
+// transactionsToTransferEdges walks a set of root transactions, and their
+// inner transactions at any depth, emitting one TransferEdge per Algos or
+// asset movement: the transaction's main transfer, plus a second edge for
+// its close-to transfer if one occurred. Transactions of other types move
+// nothing directly and contribute no edges.
+func transactionsToTransferEdges(txns []generated.Transaction) []generated.TransferEdge {
+	edges := make([]generated.TransferEdge, 0)
+	for _, txn := range txns {
+		edges = appendTransferEdges(edges, *txn.Id, txn)
+	}
+	return edges
+}
+
+func appendTransferEdges(edges []generated.TransferEdge, rootTxid string, txn generated.Transaction) []generated.TransferEdge {
+	round := *txn.ConfirmedRound
+	intra := *txn.IntraRoundOffset
+
+	switch {
+	case txn.PaymentTransaction != nil:
+		p := txn.PaymentTransaction
+		edges = append(edges, generated.TransferEdge{
+			Txid:             rootTxid,
+			Round:            round,
+			IntraRoundOffset: intra,
+			Type:             string(generated.TransactionTxTypePay),
+			Sender:           txn.Sender,
+			Receiver:         p.Receiver,
+			Amount:           p.Amount,
+		})
+		if p.CloseRemainderTo != nil && p.CloseAmount != nil && *p.CloseAmount != 0 {
+			edges = append(edges, generated.TransferEdge{
+				Txid:             rootTxid,
+				Round:            round,
+				IntraRoundOffset: intra,
+				Type:             string(generated.TransactionTxTypePay),
+				Sender:           txn.Sender,
+				Receiver:         *p.CloseRemainderTo,
+				Amount:           *p.CloseAmount,
+				CloseTo:          p.CloseRemainderTo,
+			})
+		}
+	case txn.AssetTransferTransaction != nil:
+		a := txn.AssetTransferTransaction
+		sender := txn.Sender
+		if a.Sender != nil {
+			sender = *a.Sender
+		}
+		edges = append(edges, generated.TransferEdge{
+			Txid:             rootTxid,
+			Round:            round,
+			IntraRoundOffset: intra,
+			Type:             string(generated.TransactionTxTypeAxfer),
+			Sender:           sender,
+			Receiver:         a.Receiver,
+			AssetId:          uint64Ptr(a.AssetId),
+			Amount:           a.Amount,
+		})
+		if a.CloseTo != nil && a.CloseAmount != nil && *a.CloseAmount != 0 {
+			edges = append(edges, generated.TransferEdge{
+				Txid:             rootTxid,
+				Round:            round,
+				IntraRoundOffset: intra,
+				Type:             string(generated.TransactionTxTypeAxfer),
+				Sender:           sender,
+				Receiver:         *a.CloseTo,
+				AssetId:          uint64Ptr(a.AssetId),
+				Amount:           *a.CloseAmount,
+				CloseTo:          a.CloseTo,
+			})
+		}
+	}
+
+	if txn.InnerTxns != nil {
+		for _, inner := range *txn.InnerTxns {
+			edges = appendTransferEdges(edges, rootTxid, inner)
+		}
+	}
+
+	return edges
+}
+
 //////////////////////
 // Helper functions //
 //////////////////////
 
-func min(x, y uint64) uint64 {
-	if x < y {
-		return x
+// errLimitExceeded records that a caller's limit parameter exceeded the
+// server-configured maximum for that endpoint class, so resolveLimit's
+// caller can report it as a structured 400 instead of silently truncating
+// it down to max.
+type errLimitExceeded struct {
+	limit, max uint64
+}
+
+func (e errLimitExceeded) Error() string {
+	return fmt.Sprintf("limit %d exceeds the maximum of %d allowed for this endpoint", e.limit, e.max)
+}
+
+// resolveLimit returns defaultLimit when limit is nil, or the caller's value
+// when it does not exceed maxLimit. A zero maxLimit means unlimited.
+func resolveLimit(limit *uint64, defaultLimit, maxLimit uint64) (uint64, error) {
+	if limit == nil {
+		return defaultLimit, nil
+	}
+	if maxLimit != 0 && *limit > maxLimit {
+		return 0, errLimitExceeded{limit: *limit, max: maxLimit}
+	}
+	return *limit, nil
+}
+
+// limitExceededOrBadRequest converts an error from resolving query
+// parameters into an echo response: errLimitExceeded becomes a structured
+// 400 carrying ErrCodeResultLimitExceeded, anything else becomes a plain
+// badRequest.
+func limitExceededOrBadRequest(ctx echo.Context, err error) error {
+	var limitErr errLimitExceeded
+	if errors.As(err, &limitErr) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+			Data:    errorData(ErrCodeResultLimitExceeded, "limit"),
+		})
 	}
-	return y
+	return badRequest(ctx, err.Error())
 }
@@ -57,6 +57,9 @@ var defaultOpts = ExtraOptions{
 	MaxBoxesLimit:     10000,
 	DefaultBoxesLimit: 1000,
 
+	MaxBlockHeadersLimit:     1000,
+	DefaultBlockHeadersLimit: 100,
+
 	DisabledMapConfig: MakeDisabledMapConfig(),
 }
 
@@ -1244,6 +1247,53 @@ func TestAccountClearsNonUTF8(t *testing.T) {
 	}
 }
 
+// TestSearchForAssetsReportsLifecycleRounds checks that SearchForAssets
+// reports created-at-round, destroyed-at-round and deleted for a destroyed
+// asset, matching the lifecycle fields already verified for accounts,
+// applications, and app local states.
+func TestSearchForAssetsReportsLifecycleRounds(t *testing.T) {
+	db, shutdownFunc, proc, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	assetid := uint64(1)
+	createTxn := test.MakeAssetConfigTxn(0, 100, 0, false, "unit", "asset", "", test.AccountA)
+	block, err := test.MakeBlockForTxns(test.MakeGenesisBlock().BlockHeader, &createTxn)
+	require.NoError(t, err)
+	err = proc(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	destroyTxn := test.MakeAssetDestroyTxn(assetid, test.AccountA)
+	block, err = test.MakeBlockForTxns(block.BlockHeader, &destroyTxn)
+	require.NoError(t, err)
+	err = proc(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v2/assets/")
+
+	api := testServerImplementation(db)
+	includeAll := true
+	err = api.SearchForAssets(c, generated.SearchForAssetsParams{IncludeAll: &includeAll})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response generated.AssetsResponse
+	json.Decode(rec.Body.Bytes(), &response)
+	require.Len(t, response.Assets, 1)
+
+	asset := response.Assets[0]
+	require.NotNil(t, asset.Deleted)
+	assert.True(t, *asset.Deleted)
+	require.NotNil(t, asset.CreatedAtRound)
+	assert.Equal(t, uint64(1), *asset.CreatedAtRound)
+	require.NotNil(t, asset.DestroyedAtRound)
+	assert.Equal(t, uint64(2), *asset.DestroyedAtRound)
+}
+
 // TestLookupInnerLogs runs queries for logs given application ids,
 // and checks that logs in inner transactions match properly.
 func TestLookupInnerLogs(t *testing.T) {
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// serveLive answers Kubernetes-style liveness probes. It never touches the
+// database: if the process can still execute a handler at all, it's alive.
+// Use /ready, not /live, to decide whether to route traffic to this replica.
+func serveLive(ctx echo.Context) error {
+	return ctx.String(http.StatusOK, "ok")
+}
+
+// serveReady answers Kubernetes-style readiness probes: the database must be
+// reachable, no migration may be running, and - when an algod client is
+// configured - the indexer must be within opts.MaxRoundLag rounds of algod.
+func (si *ServerImplementation) serveReady(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	health, err := si.db.Health(reqCtx)
+	if err != nil {
+		return ctx.String(http.StatusServiceUnavailable, "db health check failed: "+err.Error())
+	}
+	if !health.DBAvailable {
+		return ctx.String(http.StatusServiceUnavailable, "database not available")
+	}
+	if health.IsMigrating {
+		return ctx.String(http.StatusServiceUnavailable, "migration in progress")
+	}
+
+	if si.opts.AlgodClient != nil {
+		status, err := si.opts.AlgodClient.Status().Do(reqCtx)
+		if err != nil {
+			return ctx.String(http.StatusServiceUnavailable, "algod status check failed: "+err.Error())
+		}
+		if status.LastRound > health.Round && status.LastRound-health.Round > si.opts.MaxRoundLag {
+			return ctx.String(
+				http.StatusServiceUnavailable,
+				"round lag exceeds threshold")
+		}
+	}
+
+	return ctx.String(http.StatusOK, "ok")
+}
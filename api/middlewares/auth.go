@@ -5,39 +5,60 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 )
 
 const urlAuthFormatter = "/urlAuth/%s"
 
-type authMiddleware struct {
-	// Header is the token header which needs to be provided. For example 'X-Algod-API-Token'.
+// AuthMiddleware is the token-based auth middleware. It's exported, rather
+// than returned as a plain echo.MiddlewareFunc, so that UpdateTokens can be
+// called to hot-reload the accepted token list without restarting the
+// server.
+type AuthMiddleware struct {
+	// header is the token header which needs to be provided. For example 'X-Algod-API-Token'.
 	header string
 
-	// Tokens is the set of tokens which can be set to allow access.
+	mu     sync.RWMutex
 	tokens [][]byte
 }
 
-// MakeAuth constructs the auth middleware function
-func MakeAuth(header string, tokens []string) echo.MiddlewareFunc {
-	apiTokenBytes := make([][]byte, 0)
-	for _, token := range tokens {
-		apiTokenBytes = append(apiTokenBytes, []byte(token))
+// MakeAuth constructs the auth middleware function. An empty or later-emptied
+// token list means every request is allowed through, matching the behavior
+// of running without auth configured at all.
+func MakeAuth(header string, tokens []string) *AuthMiddleware {
+	auth := &AuthMiddleware{
+		header: header,
 	}
+	auth.UpdateTokens(tokens)
+	return auth
+}
 
-	auth := authMiddleware{
-		header: header,
-		tokens: apiTokenBytes,
+// UpdateTokens swaps in a new accepted token list, replacing the old one.
+func (auth *AuthMiddleware) UpdateTokens(tokens []string) {
+	apiTokenBytes := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		apiTokenBytes = append(apiTokenBytes, []byte(token))
 	}
 
-	return auth.handler
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+	auth.tokens = apiTokenBytes
 }
 
-// Auth takes a logger and an array of api token and return a middleware function
-// that ensures one of the api tokens was provided.
-func (auth *authMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
+// Handler is the echo.MiddlewareFunc that enforces auth.
+func (auth *AuthMiddleware) Handler(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
+		auth.mu.RLock()
+		tokens := auth.tokens
+		auth.mu.RUnlock()
+
+		// No tokens configured means auth is disabled.
+		if len(tokens) == 0 {
+			return next(ctx)
+		}
+
 		// OPTIONS responses never require auth
 		if ctx.Request().Method == "OPTIONS" {
 			return next(ctx)
@@ -69,7 +90,7 @@ func (auth *authMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		// Check the tokens in constant time
-		for _, tokenBytes := range auth.tokens {
+		for _, tokenBytes := range tokens {
 			if subtle.ConstantTimeCompare(providedToken, tokenBytes) == 1 {
 				// Token was correct, keep serving request
 				return next(ctx)
@@ -6,12 +6,18 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/algorand/indexer/api/generated/v2"
 	"github.com/algorand/indexer/idb"
 )
 
 // DBUnavailableError is the error returned when a migration is in progress or required.
 var DBUnavailableError = "Indexer DB is not available, try again later."
 
+// dbUnavailableCode is the ErrorResponse.Data["code"] value for
+// DBUnavailableError, kept as a plain string since api.ErrorCode lives in a
+// package that imports this one.
+const dbUnavailableCode = "MIGRATION_IN_PROGRESS"
+
 // MigrationMiddleware makes sure a 500 error is returned when the IndexerDb has a migration in progress.
 type MigrationMiddleware struct {
 	idb idb.IndexerDb
@@ -31,11 +37,17 @@ func (mm *MigrationMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
 		h, err := mm.idb.Health(ctx.Request().Context())
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Indexer health error: %s", err))
+			return echo.NewHTTPError(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: fmt.Sprintf("Indexer health error: %s", err),
+				Data:    &map[string]interface{}{"code": "INTERNAL_ERROR"},
+			})
 		}
 
 		if !h.DBAvailable {
-			return echo.NewHTTPError(http.StatusInternalServerError, DBUnavailableError)
+			return echo.NewHTTPError(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: DBUnavailableError,
+				Data:    &map[string]interface{}{"code": dbUnavailableCode},
+			})
 		}
 
 		return next(ctx)
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resultCountContextKey is the echo context key a handler uses to report how
+// many items it returned, for MakeResultCounter to pick up after the handler
+// runs.
+const resultCountContextKey = "result-count"
+
+// SetResultCount records the number of items a search/list handler is
+// returning, so MakeResultCounter can observe it against the per-route
+// histogram. Handlers that don't call this simply aren't counted.
+func SetResultCount(ctx echo.Context, count int) {
+	ctx.Set(resultCountContextKey, count)
+}
+
+// MakeResultCounter returns a middleware which observes, for every request a
+// handler reported a result count for via SetResultCount, a Prometheus
+// histogram of that count labeled by route. This complements the latency,
+// response size, and status code metrics echo-contrib's Prometheus
+// middleware already provides, none of which can tell how many rows a search
+// endpoint actually returned.
+func MakeResultCounter(subsystem string) echo.MiddlewareFunc {
+	resultCount := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "result_count",
+			Help:      "Number of items returned by search/list endpoints.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		},
+		[]string{"url"},
+	)
+	prometheus.MustRegister(resultCount)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			err := next(ctx)
+
+			if count, ok := ctx.Get(resultCountContextKey).(int); ok {
+				resultCount.WithLabelValues(ctx.Path()).Observe(float64(count))
+			}
+
+			return err
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
 	echo_contrib "github.com/labstack/echo-contrib/prometheus"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -73,6 +74,85 @@ type ExtraOptions struct {
 	// Boxes
 	MaxBoxesLimit     uint64
 	DefaultBoxesLimit uint64
+
+	// Block Headers
+	MaxBlockHeadersLimit     uint64
+	DefaultBlockHeadersLimit uint64
+
+	// Account State Delta
+	MaxAccountStateDeltaLimit     uint64
+	DefaultAccountStateDeltaLimit uint64
+
+	// SwaggerUIEnabled serves a bundled Swagger UI at /swagger-ui, pointed at
+	// the /swagger.json spec served by this binary. /swagger.json itself is
+	// always served, regardless of this flag.
+	SwaggerUIEnabled bool
+
+	// AlgodClient is used by GET /health?detailed=true to report the algod
+	// round and how far the indexer lags behind it. Nil when the daemon was
+	// started with --no-algod or block following is otherwise disabled; the
+	// detailed health check omits algod-derived fields in that case.
+	AlgodClient *algod.Client
+
+	// MaxRoundLag is the maximum number of rounds GET /ready will tolerate
+	// the indexer being behind algod before reporting not-ready. Ignored
+	// when AlgodClient is nil, since lag can't be measured.
+	MaxRoundLag uint64
+
+	// ShutdownTimeout bounds how long Serve waits for in-flight requests to
+	// finish, once ctx is cancelled, before forcibly closing connections.
+	// Zero means the default of one second.
+	ShutdownTimeout time.Duration
+
+	// AdminListenAddr, when non-empty, starts a second HTTP listener
+	// exposing net/http/pprof and a /debug/status endpoint (goroutine count,
+	// DB health) for diagnosing production stalls. It has no auth of its
+	// own, so it should be bound to a private address.
+	AdminListenAddr string
+
+	// ReloadCh, when non-nil, is watched for the lifetime of Serve. Each
+	// ReloadRequest received is applied to the running server - token list,
+	// query timeout - without interrupting in-flight requests or the
+	// listener. Nil fields on a ReloadRequest are left unchanged.
+	ReloadCh <-chan ReloadRequest
+
+	// CORSAllowOrigins, if non-empty, restricts the Access-Control-Allow-Origin
+	// response header to this list instead of the default "*". This lets a
+	// browser-based block explorer talk to the indexer directly, with the
+	// operator still able to pin which origins may do so.
+	CORSAllowOrigins []string
+
+	// CORSAllowMethods, if non-empty, overrides the default CORS allowed
+	// methods.
+	CORSAllowMethods []string
+
+	// CORSAllowHeaders, if non-empty, overrides the default CORS allowed
+	// headers.
+	CORSAllowHeaders []string
+
+	// CORSMaxAge is how long, in seconds, a browser may cache a CORS
+	// preflight response. Zero uses the middleware default of no caching.
+	CORSMaxAge int
+
+	// ResponseCacheSize is the number of entries kept in each of the
+	// in-process caches for block, transaction, and asset lookups. Zero
+	// disables these caches.
+	ResponseCacheSize int
+
+	// MaxTxnFieldSize is the size, in bytes, above which GET /v2/transactions
+	// elides a transaction's note, approval-program, clear-state-program, or
+	// logicsig logic field (and those of its inner transactions) when the
+	// request sets omit-large-fields=true, replacing each with its size and
+	// a sha512/256 checksum. Zero disables elision regardless of the
+	// request parameter.
+	MaxTxnFieldSize uint64
+}
+
+// ReloadRequest carries a hot-reloadable subset of ExtraOptions. A nil field
+// means "leave this setting as-is".
+type ReloadRequest struct {
+	Tokens       *[]string
+	QueryTimeout *time.Duration
 }
 
 func (e ExtraOptions) handlerTimeout() time.Duration {
@@ -100,18 +180,25 @@ func Serve(ctx context.Context, serveAddr string, db idb.IndexerDb, dataError fu
 		// This call installs the prometheus metrics collection middleware and
 		// the "/metrics" handler.
 		p.Use(e)
+
+		// echo-contrib covers latency, response size, and status codes, but
+		// has no notion of how many rows a search endpoint returned, so that
+		// gets its own middleware.
+		e.Use(middlewares.MakeResultCounter("indexer"))
 	}
 
 	e.Use(middlewares.MakeLogger(log))
-	e.Use(middleware.CORS())
+	e.Use(corsMiddleware(options))
 
 	middleware := make([]echo.MiddlewareFunc, 0)
 
 	middleware = append(middleware, middlewares.MakeMigrationMiddleware(db))
 
-	if len(options.Tokens) > 0 {
-		middleware = append(middleware, middlewares.MakeAuth("X-Indexer-API-Token", options.Tokens))
-	}
+	// auth is always installed, even with no tokens configured, so that a
+	// later ReloadRequest can turn on auth without a restart; with no tokens
+	// it allows every request through, same as omitting the middleware.
+	auth := middlewares.MakeAuth("X-Indexer-API-Token", options.Tokens)
+	middleware = append(middleware, auth.Handler)
 
 	swag, err := generated.GetSwagger()
 
@@ -132,14 +219,65 @@ func Serve(ctx context.Context, serveAddr string, db idb.IndexerDb, dataError fu
 		log:                            log,
 		disabledParams:                 disabledMap,
 		opts:                           options,
+		blockCache:                     newLRUCache(options.ResponseCacheSize),
+		txnCache:                       newLRUCache(options.ResponseCacheSize),
+		assetCache:                     newLRUCache(options.ResponseCacheSize),
 	}
 
 	generated.RegisterHandlers(e, &api, middleware...)
 	common.RegisterHandlers(e, &api)
 
+	// /swagger.json always reflects the exact spec this binary was built
+	// from, so clients can discover which parameters the running deployment
+	// actually supports.
+	e.GET("/swagger.json", func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, swag)
+	})
+	if options.SwaggerUIEnabled {
+		e.GET("/swagger-ui", serveSwaggerUI)
+	}
+
+	// /live and /ready are orchestration probes, separate from /health: /live
+	// never depends on the database so a restart doesn't mask its own
+	// progress, while /ready reports not-ready (503) while catching up past
+	// options.MaxRoundLag so a load balancer can drain a lagging replica
+	// without killing it.
+	e.GET("/live", serveLive)
+	e.GET("/ready", api.serveReady)
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
+
+	if options.AdminListenAddr != "" {
+		responseCaches := map[string]*lruCache{
+			"blocks":       api.blockCache,
+			"transactions": api.txnCache,
+			"assets":       api.assetCache,
+		}
+		go serveAdmin(ctx, options.AdminListenAddr, db, log, responseCaches)
+	}
+
+	if options.ReloadCh != nil {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case req := <-options.ReloadCh:
+					if req.Tokens != nil {
+						auth.UpdateTokens(*req.Tokens)
+						log.Info("reloaded API token list")
+					}
+					if req.QueryTimeout != nil {
+						api.setTimeout(*req.QueryTimeout)
+						log.Infof("reloaded query timeout to %s", *req.QueryTimeout)
+					}
+				}
+			}
+		}()
+	}
+
 	getctx := func(l net.Listener) context.Context {
 		return ctx
 	}
@@ -158,10 +296,15 @@ func Serve(ctx context.Context, serveAddr string, db idb.IndexerDb, dataError fu
 	}()
 
 	<-ctx.Done()
-	// Allow one second for graceful shutdown.
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+	shutdownTimeout := options.ShutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = time.Second
+	}
+	log.Infof("shutting down, draining in-flight requests for up to %s", shutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 	if err := e.Shutdown(ctx); err != nil {
-		log.Fatal(err)
+		log.Warnf("requests still in flight after %s, forcing shutdown: %v", shutdownTimeout, err)
 	}
 }
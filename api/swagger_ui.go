@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// swaggerUIPage renders a bundled Swagger UI pointed at this server's own
+// /swagger.json. It's served from a CDN rather than vendored assets, since
+// the indexer doesn't otherwise ship any static web content.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Algorand Indexer API</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({
+        url: "/swagger.json",
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`
+
+// serveSwaggerUI serves the bundled Swagger UI page, only reachable when
+// ExtraOptions.SwaggerUIEnabled is set.
+func serveSwaggerUI(ctx echo.Context) error {
+	return ctx.HTML(http.StatusOK, swaggerUIPage)
+}
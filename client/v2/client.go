@@ -0,0 +1,224 @@
+// Package client is a typed Go client for the indexer REST API, covering a
+// representative subset of endpoints. It reuses the request/response models
+// from api/generated/v2 so that client and server always agree on the wire
+// format for a given build.
+//
+// The method set intentionally mirrors a subset of api/generated/v2's
+// ServerInterface, and should be extended by hand alongside it whenever
+// api/indexer.oas3.yml gains endpoints downstream services need a typed
+// client for.
+package client
+
+//go:generate gofmt -w client.go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/algorand/indexer/api/generated/v2"
+)
+
+// Client is a typed HTTP client for the indexer API.
+type Client struct {
+	server     string
+	httpClient *http.Client
+	headers    http.Header
+}
+
+// ClientOption configures a Client returned by NewClient.
+type ClientOption func(*Client) error
+
+// WithHTTPClient overrides the http.Client used to issue requests. The
+// default is http.DefaultClient.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return fmt.Errorf("WithHTTPClient: http.Client is nil")
+		}
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithAuthToken sets the token sent on the X-Indexer-API-Token header,
+// matching the header name the server's token middleware expects.
+func WithAuthToken(token string) ClientOption {
+	return func(c *Client) error {
+		c.headers.Set("X-Indexer-API-Token", token)
+		return nil
+	}
+}
+
+// NewClient creates a Client which talks to the indexer API hosted at
+// server, e.g. "http://localhost:8980".
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		server:     strings.TrimRight(server, "/"),
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// get issues a GET request against path with the given query values and
+// decodes a JSON response body into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	u := c.server + path
+	if encoded := query.Encode(); encoded != "" {
+		u += "?" + encoded
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("client: building request: %w", err)
+	}
+	req.Header = c.headers.Clone()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("client: %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp generated.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Message != "" {
+			return fmt.Errorf("client: %s: %d: %s", path, resp.StatusCode, errResp.Message)
+		}
+		return fmt.Errorf("client: %s: unexpected status %d", path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: %s: decoding response: %w", path, err)
+	}
+	return nil
+}
+
+// HealthCheck calls GET /health.
+func (c *Client) HealthCheck(ctx context.Context) (*generated.HealthCheckResponse, error) {
+	var resp generated.HealthCheckResponse
+	if err := c.get(ctx, "/health", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LookupAccountByID calls GET /v2/accounts/{account-id}.
+func (c *Client) LookupAccountByID(ctx context.Context, accountID string, params generated.LookupAccountByIDParams) (*generated.AccountResponse, error) {
+	query := make(url.Values)
+	if params.Round != nil {
+		query.Set("round", strconv.FormatUint(*params.Round, 10))
+	}
+	if params.IncludeAll != nil {
+		query.Set("include-all", strconv.FormatBool(*params.IncludeAll))
+	}
+	if params.Exclude != nil {
+		for _, e := range *params.Exclude {
+			query.Add("exclude", string(e))
+		}
+	}
+
+	var resp generated.AccountResponse
+	path := "/v2/accounts/" + url.PathEscape(accountID)
+	if err := c.get(ctx, path, query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LookupBlock calls GET /v2/blocks/{round-number}.
+func (c *Client) LookupBlock(ctx context.Context, round uint64, params generated.LookupBlockParams) (*generated.BlockResponse, error) {
+	query := make(url.Values)
+	if params.HeaderOnly != nil {
+		query.Set("header-only", strconv.FormatBool(*params.HeaderOnly))
+	}
+
+	var resp generated.BlockResponse
+	path := "/v2/blocks/" + strconv.FormatUint(round, 10)
+	if err := c.get(ctx, path, query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchForTransactions calls GET /v2/transactions.
+func (c *Client) SearchForTransactions(ctx context.Context, params generated.SearchForTransactionsParams) (*generated.TransactionsResponse, error) {
+	query := make(url.Values)
+	if params.Limit != nil {
+		query.Set("limit", strconv.FormatUint(*params.Limit, 10))
+	}
+	if params.Next != nil {
+		query.Set("next", *params.Next)
+	}
+	if params.NotePrefix != nil {
+		query.Set("note-prefix", *params.NotePrefix)
+	}
+	if params.TxType != nil {
+		query.Set("tx-type", string(*params.TxType))
+	}
+	if params.SigType != nil {
+		query.Set("sig-type", string(*params.SigType))
+	}
+	if params.Txid != nil {
+		query.Set("txid", *params.Txid)
+	}
+	if params.Round != nil {
+		query.Set("round", strconv.FormatUint(*params.Round, 10))
+	}
+	if params.MinRound != nil {
+		query.Set("min-round", strconv.FormatUint(*params.MinRound, 10))
+	}
+	if params.MaxRound != nil {
+		query.Set("max-round", strconv.FormatUint(*params.MaxRound, 10))
+	}
+	if params.AssetId != nil {
+		query.Set("asset-id", strconv.FormatUint(*params.AssetId, 10))
+	}
+	if params.BeforeTime != nil {
+		query.Set("before-time", params.BeforeTime.Format(time.RFC3339Nano))
+	}
+	if params.AfterTime != nil {
+		query.Set("after-time", params.AfterTime.Format(time.RFC3339Nano))
+	}
+	if params.CurrencyGreaterThan != nil {
+		query.Set("currency-greater-than", strconv.FormatUint(*params.CurrencyGreaterThan, 10))
+	}
+	if params.CurrencyLessThan != nil {
+		query.Set("currency-less-than", strconv.FormatUint(*params.CurrencyLessThan, 10))
+	}
+	if params.Address != nil {
+		query.Set("address", *params.Address)
+	}
+	if params.AddressRole != nil {
+		query.Set("address-role", string(*params.AddressRole))
+	}
+	if params.ExcludeCloseTo != nil {
+		query.Set("exclude-close-to", strconv.FormatBool(*params.ExcludeCloseTo))
+	}
+	if params.RekeyTo != nil {
+		query.Set("rekey-to", strconv.FormatBool(*params.RekeyTo))
+	}
+	if params.ApplicationId != nil {
+		query.Set("application-id", strconv.FormatUint(*params.ApplicationId, 10))
+	}
+
+	var resp generated.TransactionsResponse
+	if err := c.get(ctx, "/v2/transactions", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -11,9 +11,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
 
 	"github.com/algorand/indexer/api"
 	"github.com/algorand/indexer/api/generated/v2"
@@ -29,41 +33,64 @@ import (
 )
 
 type daemonConfig struct {
-	flags                     *pflag.FlagSet
-	algodDataDir              string
-	algodAddr                 string
-	algodToken                string
-	daemonServerAddr          string
-	noAlgod                   bool
-	developerMode             bool
-	allowMigration            bool
-	metricsMode               string
-	tokenString               string
-	writeTimeout              time.Duration
-	readTimeout               time.Duration
-	maxConn                   uint32
-	maxAPIResourcesPerAccount uint32
-	maxTransactionsLimit      uint32
-	defaultTransactionsLimit  uint32
-	maxAccountsLimit          uint32
-	defaultAccountsLimit      uint32
-	maxAssetsLimit            uint32
-	defaultAssetsLimit        uint32
-	maxBoxesLimit             uint32
-	defaultBoxesLimit         uint32
-	maxBalancesLimit          uint32
-	defaultBalancesLimit      uint32
-	maxApplicationsLimit      uint32
-	defaultApplicationsLimit  uint32
-	enableAllParameters       bool
-	indexerDataDir            string
-	initLedger                bool
-	catchpoint                string
-	cpuProfile                string
-	pidFilePath               string
-	configFile                string
-	suppliedAPIConfigFile     string
-	genesisJSONPath           string
+	flags                         *pflag.FlagSet
+	algodDataDir                  string
+	algodAddr                     string
+	algodToken                    string
+	daemonServerAddr              string
+	noAlgod                       bool
+	developerMode                 bool
+	allowMigration                bool
+	allowUnsupportedSchema        bool
+	metricsMode                   string
+	tokenString                   string
+	writeTimeout                  time.Duration
+	readTimeout                   time.Duration
+	maxConn                       uint32
+	vacuumInterval                time.Duration
+	maxBatchStatements            uint32
+	dryRun                        bool
+	enableAppStateHistory         bool
+	enableBalanceHistory          bool
+	enableRewardsHistory          bool
+	enableOnlineStakeHistory      bool
+	swaggerUIEnabled              bool
+	maxRoundLag                   uint64
+	shutdownTimeout               time.Duration
+	adminListenAddr               string
+	hotReloadFile                 string
+	corsAllowOrigins              []string
+	corsAllowMethods              []string
+	corsAllowHeaders              []string
+	corsMaxAge                    int
+	responseCacheSize             int
+	maxAPIResourcesPerAccount     uint32
+	maxTransactionsLimit          uint32
+	defaultTransactionsLimit      uint32
+	maxAccountsLimit              uint32
+	defaultAccountsLimit          uint32
+	maxAssetsLimit                uint32
+	defaultAssetsLimit            uint32
+	maxBoxesLimit                 uint32
+	defaultBoxesLimit             uint32
+	maxBlockHeadersLimit          uint32
+	defaultBlockHeadersLimit      uint32
+	maxAccountStateDeltaLimit     uint32
+	defaultAccountStateDeltaLimit uint32
+	maxTxnFieldSize               uint32
+	maxBalancesLimit              uint32
+	defaultBalancesLimit          uint32
+	maxApplicationsLimit          uint32
+	defaultApplicationsLimit      uint32
+	enableAllParameters           bool
+	indexerDataDir                string
+	initLedger                    bool
+	catchpoint                    string
+	cpuProfile                    string
+	pidFilePath                   string
+	configFile                    string
+	suppliedAPIConfigFile         string
+	genesisJSONPath               string
 }
 
 // DaemonCmd creates the main cobra command, initializes flags, and viper aliases
@@ -91,10 +118,28 @@ func DaemonCmd() *cobra.Command {
 	cfg.flags.StringVarP(&cfg.tokenString, "token", "t", "", "an optional auth token, when set REST calls must use this token in a bearer format, or in a 'X-Indexer-API-Token' header")
 	cfg.flags.BoolVarP(&cfg.developerMode, "dev-mode", "", false, "allow performance intensive operations like searching for accounts at a particular round")
 	cfg.flags.BoolVarP(&cfg.allowMigration, "allow-migration", "", false, "allow migrations to happen even when no algod connected")
+	cfg.flags.BoolVarP(&cfg.allowUnsupportedSchema, "allow-unsupported-schema", "", false, "in read-only mode (no algod, migrations disallowed), serve reads even if the DB schema is newer than this binary supports; normally refuses to start")
 	cfg.flags.StringVarP(&cfg.metricsMode, "metrics-mode", "", "OFF", "configure the /metrics endpoint to [ON, OFF, VERBOSE]")
 	cfg.flags.DurationVarP(&cfg.writeTimeout, "write-timeout", "", 30*time.Second, "set the maximum duration to wait before timing out writes to a http response, breaking connection")
 	cfg.flags.DurationVarP(&cfg.readTimeout, "read-timeout", "", 5*time.Second, "set the maximum duration for reading the entire request")
 	cfg.flags.Uint32VarP(&cfg.maxConn, "max-conn", "", 0, "set the maximum connections allowed in the connection pool, if the maximum is reached subsequent connections will wait until a connection becomes available, or timeout according to the read-timeout setting")
+	cfg.flags.DurationVarP(&cfg.vacuumInterval, "vacuum-interval", "", 0, "run the built-in maintenance scheduler (ANALYZE/VACUUM of hot tables, bloat reporting) on this interval; zero disables it")
+	cfg.flags.Uint32VarP(&cfg.maxBatchStatements, "max-batch-statements", "", 0, "bound how many statements the accounting stage queues into a single batch before flushing it to the database, so a round with many account/asset/app changes doesn't build one unbounded batch; zero uses the backend's own default")
+	cfg.flags.BoolVarP(&cfg.dryRun, "dry-run", "", false, "run the import pipeline against the configured database without persisting any changes, for soak-testing new releases against live traffic")
+	cfg.flags.BoolVarP(&cfg.enableAppStateHistory, "enable-app-state-history", "", false, "record every application global/local state key change into a history table, so application and local-state lookups can be queried as of a past round; adds a write per changed key per round")
+	cfg.flags.BoolVarP(&cfg.enableBalanceHistory, "enable-balance-history", "", false, "record each account's balance into a history table for every round in which the account is updated, so GET /v2/accounts/{addr}/balance-history can return a time series; adds a write per updated account per round")
+	cfg.flags.BoolVarP(&cfg.enableRewardsHistory, "enable-rewards-history", "", false, "record each account's rewards total into a history table for every round in which the account is updated, so GET /v2/accounts/{addr}/rewards can summarize rewards earned over a round range; adds a write per updated account per round")
+	cfg.flags.BoolVarP(&cfg.enableOnlineStakeHistory, "enable-online-stake-history", "", false, "record the network's total online stake into a history table every round, so GET /v2/online-stake-history can return a time series; adds a write per round")
+	cfg.flags.BoolVarP(&cfg.swaggerUIEnabled, "enable-swagger-ui", "", false, "serve a bundled Swagger UI at /swagger-ui; /swagger.json is always served")
+	cfg.flags.Uint64VarP(&cfg.maxRoundLag, "max-round-lag", "", 10, "maximum number of rounds behind algod before GET /ready reports not-ready")
+	cfg.flags.DurationVarP(&cfg.shutdownTimeout, "shutdown-timeout", "", 5*time.Second, "on SIGTERM/SIGINT, how long to wait for in-flight API requests to finish before forcing shutdown")
+	cfg.flags.StringVarP(&cfg.adminListenAddr, "admin-listen-address", "", "", "if set, serve net/http/pprof and /debug/status on this address (e.g. localhost:9999); has no auth of its own")
+	cfg.flags.StringVarP(&cfg.hotReloadFile, "hot-reload-file", "", "", "path to a YAML file (tokens, query-timeout, log-level) re-read on SIGHUP to reconfigure the running daemon without a restart")
+	cfg.flags.StringSliceVarP(&cfg.corsAllowOrigins, "cors-allow-origin", "", nil, "origin allowed to make cross-origin requests to the API; may be repeated. Defaults to allowing any origin")
+	cfg.flags.StringSliceVarP(&cfg.corsAllowMethods, "cors-allow-methods", "", nil, "HTTP method allowed for cross-origin requests; may be repeated. Defaults to echo's standard method set")
+	cfg.flags.StringSliceVarP(&cfg.corsAllowHeaders, "cors-allow-headers", "", nil, "HTTP header allowed for cross-origin requests; may be repeated")
+	cfg.flags.IntVarP(&cfg.corsMaxAge, "cors-max-age", "", 0, "seconds a browser may cache a CORS preflight response; zero disables caching")
+	cfg.flags.IntVarP(&cfg.responseCacheSize, "response-cache-size", "", 1000, "number of entries kept in each in-process response cache (blocks, transactions by ID, asset params); zero disables these caches")
 
 	cfg.flags.StringVar(&cfg.suppliedAPIConfigFile, "api-config-file", "", "supply an API config file to enable/disable parameters")
 	cfg.flags.BoolVar(&cfg.enableAllParameters, "enable-all-parameters", false, "override default configuration and enable all parameters. Can't be used with --api-config-file")
@@ -111,6 +156,11 @@ func DaemonCmd() *cobra.Command {
 	cfg.flags.Uint32VarP(&cfg.defaultApplicationsLimit, "default-applications-limit", "", 100, "set the default Limit parameter for querying applications, if none is provided")
 	cfg.flags.Uint32VarP(&cfg.maxBoxesLimit, "max-boxes-limit", "", 10000, "set the maximum allowed Limit parameter for searching an app's boxes")
 	cfg.flags.Uint32VarP(&cfg.defaultBoxesLimit, "default-boxes-limit", "", 1000, "set the default allowed Limit parameter for searching an app's boxes")
+	cfg.flags.Uint32VarP(&cfg.maxBlockHeadersLimit, "max-block-headers-limit", "", 1000, "set the maximum allowed Limit parameter for searching block headers")
+	cfg.flags.Uint32VarP(&cfg.defaultBlockHeadersLimit, "default-block-headers-limit", "", 100, "set the default allowed Limit parameter for searching block headers")
+	cfg.flags.Uint32VarP(&cfg.maxAccountStateDeltaLimit, "max-account-state-delta-limit", "", 1000, "set the maximum allowed Limit parameter for searching account state deltas")
+	cfg.flags.Uint32VarP(&cfg.defaultAccountStateDeltaLimit, "default-account-state-delta-limit", "", 100, "set the default Limit parameter for searching account state deltas, if none is provided")
+	cfg.flags.Uint32VarP(&cfg.maxTxnFieldSize, "max-txn-size", "", 4096, "set the size, in bytes, above which searchForTransactions elides a transaction's note/approval-program/clear-state-program/logic field when the request sets omit-large-fields=true. Set zero to disable elision regardless of the request parameter")
 
 	cfg.flags.StringVarP(&cfg.indexerDataDir, "data-dir", "i", "", "path to indexer data dir, or $INDEXER_DATA")
 	cfg.flags.BoolVar(&cfg.initLedger, "init-ledger", true, "initialize local ledger using sequential mode")
@@ -211,6 +261,78 @@ func loadIndexerParamConfig(cfg *daemonConfig) error {
 	return err
 }
 
+// hotReloadConfig is the shape of the --hot-reload-file. It's re-read on
+// every SIGHUP; any field left out of the file is treated as "no change" so
+// an operator can update just the token list without also restating the
+// query timeout and log level.
+type hotReloadConfig struct {
+	Tokens       []string `yaml:"tokens"`
+	QueryTimeout string   `yaml:"query-timeout"`
+	LogLevel     string   `yaml:"log-level"`
+}
+
+func loadHotReloadConfig(path string) (*hotReloadConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("hot reload file: %w", err)
+	}
+	defer f.Close()
+
+	var cfg hotReloadConfig
+	if err := yaml.NewDecoder(f).Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("hot reload file (%s): %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// watchHotReload re-reads daemonConfig.hotReloadFile on every SIGHUP and
+// applies the log level directly, while forwarding the token list and query
+// timeout to Serve via reloadCh. Restarting the daemon to pick up a new
+// token or to temporarily widen a slow-query timeout would interrupt
+// catchup and drop API traffic, which is what this avoids.
+func watchHotReload(ctx context.Context, path string, reloadCh chan<- api.ReloadRequest) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			cfg, err := loadHotReloadConfig(path)
+			if err != nil {
+				logger.WithError(err).Errorf("hot reload failed, keeping previous configuration")
+				continue
+			}
+
+			if cfg.LogLevel != "" {
+				level, err := logrus.ParseLevel(cfg.LogLevel)
+				if err != nil {
+					logger.WithError(err).Errorf("hot reload: invalid log-level %q", cfg.LogLevel)
+				} else {
+					logger.SetLevel(level)
+					logger.Infof("hot reloaded log level to %s", level)
+				}
+			}
+
+			req := api.ReloadRequest{}
+			if cfg.Tokens != nil {
+				req.Tokens = &cfg.Tokens
+			}
+			if cfg.QueryTimeout != "" {
+				timeout, err := time.ParseDuration(cfg.QueryTimeout)
+				if err != nil {
+					logger.WithError(err).Errorf("hot reload: invalid query-timeout %q", cfg.QueryTimeout)
+				} else {
+					req.QueryTimeout = &timeout
+				}
+			}
+			reloadCh <- req
+		}
+	}
+}
+
 func runDaemon(daemonConfig *daemonConfig) error {
 	var err error
 
@@ -322,9 +444,17 @@ func runDaemon(daemonConfig *daemonConfig) error {
 	opts := idb.IndexerDbOptions{}
 	if daemonConfig.noAlgod && !daemonConfig.allowMigration {
 		opts.ReadOnly = true
+		opts.AllowUnsupportedSchema = daemonConfig.allowUnsupportedSchema
 	}
 
 	opts.MaxConn = daemonConfig.maxConn
+	opts.VacuumInterval = daemonConfig.vacuumInterval
+	opts.MaxBatchStatements = daemonConfig.maxBatchStatements
+	opts.DryRun = daemonConfig.dryRun
+	opts.EnableAppStateHistory = daemonConfig.enableAppStateHistory
+	opts.EnableBalanceHistory = daemonConfig.enableBalanceHistory
+	opts.EnableRewardsHistory = daemonConfig.enableRewardsHistory
+	opts.EnableOnlineStakeHistory = daemonConfig.enableOnlineStakeHistory
 	opts.IndexerDatadir = daemonConfig.indexerDataDir
 	opts.AlgodDataDir = daemonConfig.algodDataDir
 	opts.AlgodToken = daemonConfig.algodToken
@@ -349,7 +479,11 @@ func runDaemon(daemonConfig *daemonConfig) error {
 		pipeline := runConduitPipeline(ctx, nextRound, daemonConfig)
 		if pipeline != nil {
 			dataError = pipeline.Error
-			defer pipeline.Stop()
+			defer func() {
+				logger.Info("waiting for the current round's import to finish before exiting")
+				pipeline.Stop()
+				logger.Info("import stopped cleanly")
+			}()
 		}
 	} else {
 		logger.Info("No block importer configured.")
@@ -359,6 +493,24 @@ func runDaemon(daemonConfig *daemonConfig) error {
 	logger.Infof("serving on %s", daemonConfig.daemonServerAddr)
 
 	options := makeOptions(daemonConfig)
+	if !daemonConfig.noAlgod {
+		algodAddr := daemonConfig.algodAddr
+		if !strings.HasPrefix(algodAddr, "http") {
+			algodAddr = "http://" + algodAddr
+		}
+		client, err := algod.MakeClient(algodAddr, daemonConfig.algodToken)
+		if err != nil {
+			logger.Warnf("unable to make algod client for detailed health checks: %v", err)
+		} else {
+			options.AlgodClient = client
+		}
+	}
+
+	if daemonConfig.hotReloadFile != "" {
+		reloadCh := make(chan api.ReloadRequest)
+		options.ReloadCh = reloadCh
+		go watchHotReload(ctx, daemonConfig.hotReloadFile, reloadCh)
+	}
 
 	api.Serve(ctx, daemonConfig.daemonServerAddr, db, dataError, logger, options)
 	return err
@@ -396,9 +548,14 @@ func makeConduitConfig(dCfg *daemonConfig, nextRound uint64) pipeline.Config {
 		Exporter: pipeline.NameConfigPair{
 			Name: "postgresql",
 			Config: map[string]interface{}{
-				"connection-string": postgresAddr,
-				"max-conn":          dCfg.maxConn,
-				"test":              dummyIndexerDb,
+				"connection-string":           postgresAddr,
+				"max-conn":                    dCfg.maxConn,
+				"test":                        dummyIndexerDb,
+				"dry-run":                     dCfg.dryRun,
+				"enable-app-state-history":    dCfg.enableAppStateHistory,
+				"enable-balance-history":      dCfg.enableBalanceHistory,
+				"enable-rewards-history":      dCfg.enableRewardsHistory,
+				"enable-online-stake-history": dCfg.enableOnlineStakeHistory,
 			},
 		},
 	}
@@ -445,6 +602,15 @@ func makeOptions(daemonConfig *daemonConfig) (options api.ExtraOptions) {
 	}
 	options.WriteTimeout = daemonConfig.writeTimeout
 	options.ReadTimeout = daemonConfig.readTimeout
+	options.SwaggerUIEnabled = daemonConfig.swaggerUIEnabled
+	options.MaxRoundLag = daemonConfig.maxRoundLag
+	options.ShutdownTimeout = daemonConfig.shutdownTimeout
+	options.AdminListenAddr = daemonConfig.adminListenAddr
+	options.CORSAllowOrigins = daemonConfig.corsAllowOrigins
+	options.CORSAllowMethods = daemonConfig.corsAllowMethods
+	options.CORSAllowHeaders = daemonConfig.corsAllowHeaders
+	options.CORSMaxAge = daemonConfig.corsMaxAge
+	options.ResponseCacheSize = daemonConfig.responseCacheSize
 
 	options.MaxAPIResourcesPerAccount = uint64(daemonConfig.maxAPIResourcesPerAccount)
 	options.MaxTransactionsLimit = uint64(daemonConfig.maxTransactionsLimit)
@@ -459,6 +625,11 @@ func makeOptions(daemonConfig *daemonConfig) (options api.ExtraOptions) {
 	options.DefaultApplicationsLimit = uint64(daemonConfig.defaultApplicationsLimit)
 	options.MaxBoxesLimit = uint64(daemonConfig.maxBoxesLimit)
 	options.DefaultBoxesLimit = uint64(daemonConfig.defaultBoxesLimit)
+	options.MaxBlockHeadersLimit = uint64(daemonConfig.maxBlockHeadersLimit)
+	options.DefaultBlockHeadersLimit = uint64(daemonConfig.defaultBlockHeadersLimit)
+	options.MaxAccountStateDeltaLimit = uint64(daemonConfig.maxAccountStateDeltaLimit)
+	options.DefaultAccountStateDeltaLimit = uint64(daemonConfig.defaultAccountStateDeltaLimit)
+	options.MaxTxnFieldSize = uint64(daemonConfig.maxTxnFieldSize)
 
 	if daemonConfig.enableAllParameters {
 		options.DisabledMapConfig = api.MakeDisabledMapConfig()
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/indexer/config"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres"
+)
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "db-status",
+	Short: "print schema/migration status",
+	Long:  "Connect to the configured database, print its schema/migration number and any pending migrations, and exit without starting the daemon.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.BindFlagSet(cmd.Flags())
+		err := configureLogger()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to configure logger: %v\n", err)
+			panic(exit{1})
+		}
+
+		// AllowUnsupportedSchema so this command can always report status,
+		// even against a schema newer than this binary supports.
+		db, _, err := indexerDbFromFlags(idb.IndexerDbOptions{ReadOnly: true, AllowUnsupportedSchema: true})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not connect to db: %v\n", err)
+			panic(exit{1})
+		}
+		defer db.Close()
+
+		health, err := db.Health(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not fetch db status: %v\n", err)
+			panic(exit{1})
+		}
+
+		var schemaVersion interface{}
+		var schemaCompatible interface{}
+		var pending []postgres.PendingMigration
+		if health.Data != nil {
+			schemaVersion = (*health.Data)["schema-migration-number"]
+			schemaCompatible = (*health.Data)["schema-compatible"]
+			pending, _ = (*health.Data)["pending-migrations"].([]postgres.PendingMigration)
+		}
+
+		fmt.Printf("schema/migration number: %v\n", schemaVersion)
+		fmt.Printf("binary supports this schema: %v\n", schemaCompatible)
+		fmt.Printf("migration required: %v\n", len(pending) > 0)
+		fmt.Printf("blocks reads/writes until complete: %v\n", !health.DBAvailable)
+
+		if len(pending) == 0 {
+			fmt.Println("no pending migrations")
+			panic(exit{0})
+		}
+
+		fmt.Println("pending migrations:")
+		for _, m := range pending {
+			fmt.Printf("  - blocking=%v %s\n", m.Blocking, m.Description)
+		}
+
+		panic(exit{0})
+	},
+}
+
+func init() {
+	dbStatusCmd.Flags().StringVarP(&postgresAddr, "postgres", "P", "", "connection string for postgres database")
+	dbStatusCmd.Flags().StringVarP(&logLevel, "loglevel", "l", "info", "verbosity of logs: [error, warn, info, debug, trace]")
+	rootCmd.AddCommand(dbStatusCmd)
+}
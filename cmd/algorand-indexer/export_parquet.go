@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/indexer/config"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/util/parquetexport"
+)
+
+var (
+	exportOutDir          string
+	exportStartRound      uint64
+	exportEndRound        uint64
+	exportPartitionRounds uint64
+	exportIncludeAccounts bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "bulk-export indexer data for analytics",
+	Long:  "Commands that stream data out of the configured database into formats meant for analytics tooling, as opposed to the query API.",
+}
+
+var exportParquetCmd = &cobra.Command{
+	Use:   "parquet",
+	Short: "export a round range of transactions (and optionally accounts) to Parquet",
+	Long: "Stream transactions in [start-round, end-round] into partitioned Parquet files under out-dir/transactions, " +
+		"and optionally a current account snapshot into out-dir/accounts/accounts.parquet, so data teams can load " +
+		"indexer data into Spark/BigQuery without custom ETL against the Postgres schema or JSON API.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.BindFlagSet(cmd.Flags())
+		err := configureLogger()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to configure logger: %v\n", err)
+			panic(exit{1})
+		}
+
+		if exportEndRound < exportStartRound {
+			fmt.Fprintf(os.Stderr, "end-round must be >= start-round\n")
+			panic(exit{1})
+		}
+
+		db, _, err := indexerDbFromFlags(idb.IndexerDbOptions{ReadOnly: true})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not connect to db: %v\n", err)
+			panic(exit{1})
+		}
+		defer db.Close()
+
+		if err := os.MkdirAll(exportOutDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "could not create out-dir: %v\n", err)
+			panic(exit{1})
+		}
+
+		ctx := context.Background()
+
+		numTxns, err := exportTransactions(ctx, db, exportOutDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			panic(exit{1})
+		}
+		logger.Infof("wrote %d transactions to %s/transactions", numTxns, exportOutDir)
+
+		if exportIncludeAccounts {
+			numAccounts, err := exportAccounts(ctx, db, exportOutDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				panic(exit{1})
+			}
+			logger.Infof("wrote %d accounts to %s/accounts", numAccounts, exportOutDir)
+		}
+
+		panic(exit{0})
+	},
+}
+
+func exportTransactions(ctx context.Context, db idb.IndexerDb, outDir string) (uint64, error) {
+	pw, err := parquetexport.NewPartitionedWriter(outDir, exportPartitionRounds)
+	if err != nil {
+		return 0, fmt.Errorf("could not open transactions writer: %w", err)
+	}
+	defer pw.Close()
+
+	rowChan, _ := db.Transactions(ctx, idb.TransactionFilter{MinRound: exportStartRound, MaxRound: exportEndRound})
+
+	var count uint64
+	for txnRow := range rowChan {
+		row, err := parquetexport.NewTransactionRow(txnRow)
+		if err != nil {
+			return count, fmt.Errorf("error reading transaction at round %d: %w", txnRow.Round, err)
+		}
+		if err := pw.WriteTransaction(row); err != nil {
+			return count, fmt.Errorf("error writing transaction: %w", err)
+		}
+		count++
+	}
+
+	if err := pw.Close(); err != nil {
+		return count, fmt.Errorf("could not finalize transactions export: %w", err)
+	}
+	return count, nil
+}
+
+func exportAccounts(ctx context.Context, db idb.IndexerDb, outDir string) (uint64, error) {
+	aw, err := parquetexport.NewAccountWriter(outDir)
+	if err != nil {
+		return 0, fmt.Errorf("could not open accounts writer: %w", err)
+	}
+	defer aw.Close()
+
+	acctChan, _ := db.GetAccounts(ctx, idb.AccountQueryOptions{})
+
+	var count uint64
+	for acctRow := range acctChan {
+		if acctRow.Error != nil {
+			return count, fmt.Errorf("error reading account: %w", acctRow.Error)
+		}
+		if err := aw.WriteAccount(parquetexport.NewAccountRow(acctRow.Account)); err != nil {
+			return count, fmt.Errorf("error writing account: %w", err)
+		}
+		count++
+	}
+
+	if err := aw.Close(); err != nil {
+		return count, fmt.Errorf("could not finalize accounts export: %w", err)
+	}
+	return count, nil
+}
+
+func init() {
+	exportParquetCmd.Flags().StringVarP(&postgresAddr, "postgres", "P", "", "connection string for postgres database")
+	exportParquetCmd.Flags().StringVarP(&logLevel, "loglevel", "l", "info", "verbosity of logs: [error, warn, info, debug, trace]")
+	exportParquetCmd.Flags().StringVar(&exportOutDir, "out-dir", "", "directory to write Parquet files to (required)")
+	exportParquetCmd.Flags().Uint64Var(&exportStartRound, "start-round", 0, "first round to include, inclusive")
+	exportParquetCmd.Flags().Uint64Var(&exportEndRound, "end-round", 0, "last round to include, inclusive; 0 means no upper bound")
+	exportParquetCmd.Flags().Uint64Var(&exportPartitionRounds, "partition-rounds", 100000, "number of rounds per transactions Parquet file")
+	exportParquetCmd.Flags().BoolVar(&exportIncludeAccounts, "accounts", false, "also export a current account snapshot to out-dir/accounts")
+	exportParquetCmd.MarkFlagRequired("out-dir")
+
+	exportCmd.AddCommand(exportParquetCmd)
+	rootCmd.AddCommand(exportCmd)
+}
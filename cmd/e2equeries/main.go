@@ -89,6 +89,8 @@ func main() {
 		printAccountQuery(db, idb.AccountQueryOptions{HasAssetID: bestid, Limit: bestcount})
 	}
 
+	checkInnerTxnIndexing(db)
+
 	dt := time.Since(start)
 	exitValue := testutil.ExitValue()
 	if exitValue == 0 {
@@ -98,3 +100,49 @@ func main() {
 	}
 	os.Exit(exitValue)
 }
+
+// checkInnerTxnIndexing looks for an application call that produced inner
+// transactions, then confirms that searching by one inner transaction's
+// sender and type finds its root. This exercises inner txn indexing as
+// actually written by the fetcher/importer pipeline against a live network,
+// rather than against synthetic blocks fed straight to the processor as the
+// Go test suite does. Recorded networks aren't guaranteed to contain an app
+// call with inner transactions, so finding none is reported but not fatal.
+func checkInnerTxnIndexing(db idb.IndexerDb) {
+	rowchan, _ := db.Transactions(context.Background(), idb.TransactionFilter{TypeEnum: idb.TypeEnumApplication, Limit: 1000})
+	for txnrow := range rowchan {
+		maybeFail(txnrow.Error, "err appl txn %v\n", txnrow.Error)
+		if txnrow.Txn == nil || len(txnrow.Txn.ApplyData.EvalDelta.InnerTxns) == 0 {
+			continue
+		}
+
+		inner := txnrow.Txn.ApplyData.EvalDelta.InnerTxns[0]
+		innerType, ok := idb.GetTypeEnum(inner.Txn.Type)
+		if !ok {
+			continue
+		}
+
+		innerFilter := idb.TransactionFilter{
+			Address:     inner.Txn.Sender[:],
+			AddressRole: idb.AddressRoleSender,
+			TypeEnum:    innerType,
+			Limit:       10,
+		}
+		innerchan, _ := db.Transactions(context.Background(), innerFilter)
+		found := false
+		for ir := range innerchan {
+			maybeFail(ir.Error, "err inner txn lookup %v\n", ir.Error)
+			// The first inner transaction is stored at the root's intra + 1.
+			if ir.Round == txnrow.Round && ir.RootTxn != nil && ir.Intra == txnrow.Intra+1 {
+				found = true
+			}
+		}
+		if found {
+			testutil.Info("inner txn indexing OK (round=%d intra=%d)\n", txnrow.Round, txnrow.Intra)
+			return
+		}
+		testutil.Fail("inner txn at round=%d intra=%d not found when searching by its sender/type\n", txnrow.Round, txnrow.Intra)
+		return
+	}
+	testutil.Info("no application call with inner transactions found, skipping inner txn indexing check\n")
+}
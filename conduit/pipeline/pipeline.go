@@ -425,16 +425,26 @@ func (p *pipelineImpl) Start() {
 			default:
 				{
 					p.logger.Infof("Pipeline round: %v", p.pipelineMetadata.NextRound)
-					// fetch block
-					importStart := time.Now()
-					blkData, err := (*p.importer).GetBlock(p.pipelineMetadata.NextRound)
+					// fetch block, resuming from a copy cached by an interrupted
+					// run instead of re-fetching it from the importer
+					blkData, cached, err := p.loadCachedBlock(p.pipelineMetadata.NextRound)
 					if err != nil {
 						p.logger.Errorf("%v", err)
-						p.setError(err)
-						retry++
-						goto pipelineRun
 					}
-					metrics.ImporterTimeSeconds.Observe(time.Since(importStart).Seconds())
+					if !cached {
+						importStart := time.Now()
+						blkData, err = (*p.importer).GetBlock(p.pipelineMetadata.NextRound)
+						if err != nil {
+							p.logger.Errorf("%v", err)
+							p.setError(err)
+							retry++
+							goto pipelineRun
+						}
+						metrics.ImporterTimeSeconds.Observe(time.Since(importStart).Seconds())
+						if err := p.cacheBlock(blkData); err != nil {
+							p.logger.Errorf("%v", err)
+						}
+					}
 					// Start time currently measures operations after block fetching is complete.
 					// This is for backwards compatibility w/ Indexer's metrics
 					// run through processors
@@ -461,6 +471,7 @@ func (p *pipelineImpl) Start() {
 					}
 
 					// Increment Round, update metadata
+					p.removeCachedBlock(blkData.Round())
 					p.pipelineMetadata.NextRound++
 					err = p.encodeMetadataToFile()
 					if err != nil {
@@ -519,6 +530,67 @@ func (p *pipelineImpl) encodeMetadataToFile() error {
 	return nil
 }
 
+func blockCacheDir(dataDir string) string {
+	return path.Join(dataDir, "blockdata")
+}
+
+func blockCachePath(dataDir string, round uint64) string {
+	return path.Join(blockCacheDir(dataDir), fmt.Sprintf("%d.json", round))
+}
+
+// cacheBlock durably persists a block fetched from the importer but not yet
+// run through the processors/exporter, so a crash in between doesn't
+// require re-fetching it on restart.
+func (p *pipelineImpl) cacheBlock(blkData data.BlockData) error {
+	dataDir := p.cfg.ConduitArgs.ConduitDataDir
+	if err := os.MkdirAll(blockCacheDir(dataDir), os.ModePerm); err != nil {
+		return fmt.Errorf("cacheBlock(): failed to create block cache dir: %w", err)
+	}
+
+	blockCacheFilePath := blockCachePath(dataDir, blkData.Round())
+	tempFilename := fmt.Sprintf("%s.temp", blockCacheFilePath)
+	file, err := os.Create(tempFilename)
+	if err != nil {
+		return fmt.Errorf("cacheBlock(): failed to create temp block cache file: %w", err)
+	}
+	defer file.Close()
+	if err := json.NewEncoder(file).Encode(blkData); err != nil {
+		return fmt.Errorf("cacheBlock(): failed to write temp block cache file: %w", err)
+	}
+
+	if err := os.Rename(tempFilename, blockCacheFilePath); err != nil {
+		return fmt.Errorf("cacheBlock(): failed to replace block cache file: %w", err)
+	}
+	return nil
+}
+
+// loadCachedBlock returns the block cached for round by a previous,
+// interrupted run, if any.
+func (p *pipelineImpl) loadCachedBlock(round uint64) (data.BlockData, bool, error) {
+	contents, err := os.ReadFile(blockCachePath(p.cfg.ConduitArgs.ConduitDataDir, round))
+	if errors.Is(err, os.ErrNotExist) {
+		return data.BlockData{}, false, nil
+	}
+	if err != nil {
+		return data.BlockData{}, false, fmt.Errorf("loadCachedBlock(): failed to read block cache file: %w", err)
+	}
+
+	var blkData data.BlockData
+	if err := json.Unmarshal(contents, &blkData); err != nil {
+		return data.BlockData{}, false, fmt.Errorf("loadCachedBlock(): failed to decode block cache file: %w", err)
+	}
+	return blkData, true, nil
+}
+
+// removeCachedBlock deletes the cached copy of round once it has been
+// durably exported and no longer needs to survive a crash.
+func (p *pipelineImpl) removeCachedBlock(round uint64) {
+	blockCacheFilePath := blockCachePath(p.cfg.ConduitArgs.ConduitDataDir, round)
+	if err := os.Remove(blockCacheFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		p.logger.Errorf("removeCachedBlock(): failed to remove block cache file %s: %v", blockCacheFilePath, err)
+	}
+}
+
 func (p *pipelineImpl) initializeOrLoadBlockMetadata() (state, error) {
 	pipelineMetadataFilePath := metadataPath(p.cfg.ConduitArgs.ConduitDataDir)
 	if stat, err := os.Stat(pipelineMetadataFilePath); errors.Is(err, os.ErrNotExist) || (stat != nil && stat.Size() == 0) {
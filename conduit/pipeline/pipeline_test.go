@@ -703,6 +703,41 @@ func TestBlockMetaDataFile(t *testing.T) {
 	assert.Contains(t, err.Error(), "encodeMetadataToFile(): failed to create temp metadata file")
 }
 
+// TestBlockCacheResume tests that a block cached by cacheBlock() is returned by
+// loadCachedBlock(), and that removeCachedBlock() deletes it once it has been
+// durably exported.
+func TestBlockCacheResume(t *testing.T) {
+	datadir := t.TempDir()
+	l, _ := test.NewNullLogger()
+	pImpl := pipelineImpl{
+		cfg: &Config{
+			ConduitArgs: &conduit.Args{
+				ConduitDataDir: datadir,
+			},
+		},
+		logger: l,
+	}
+
+	// no cached block yet
+	_, cached, err := pImpl.loadCachedBlock(5)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+
+	blkData := data.BlockData{BlockHeader: bookkeeping.BlockHeader{Round: 5}}
+	err = pImpl.cacheBlock(blkData)
+	assert.NoError(t, err)
+
+	loaded, cached, err := pImpl.loadCachedBlock(5)
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, blkData.Round(), loaded.Round())
+
+	pImpl.removeCachedBlock(5)
+	_, cached, err = pImpl.loadCachedBlock(5)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+}
+
 func TestGenesisHash(t *testing.T) {
 	var pImporter importers.Importer = &mockImporter{genesis: bookkeeping.Genesis{Network: "test"}}
 	var pProcessor processors.Processor = &mockProcessor{}
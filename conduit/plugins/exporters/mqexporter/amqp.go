@@ -0,0 +1,54 @@
+package mqexporter
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// amqpPublisher publishes to an AMQP 0.9.1 exchange (or, if Exchange is
+// empty, directly to a queue named RoutingKey) over a single channel.
+type amqpPublisher struct {
+	cfg  Config
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+func newAMQPPublisher(cfg Config) (*amqpPublisher, error) {
+	if cfg.RoutingKey == "" {
+		return nil, fmt.Errorf("routing-key is required for backend %q", BackendAMQP)
+	}
+
+	conn, err := amqp.Dial(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", cfg.URL, err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not open channel: %w", err)
+	}
+
+	if cfg.Exchange == "" {
+		if _, err := ch.QueueDeclare(cfg.RoutingKey, true, false, false, false, nil); err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("could not declare queue %s: %w", cfg.RoutingKey, err)
+		}
+	}
+
+	return &amqpPublisher{cfg: cfg, conn: conn, ch: ch}, nil
+}
+
+func (p *amqpPublisher) Publish(body []byte) error {
+	return p.ch.Publish(p.cfg.Exchange, p.cfg.RoutingKey, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+	})
+}
+
+func (p *amqpPublisher) Close() error {
+	p.ch.Close()
+	return p.conn.Close()
+}
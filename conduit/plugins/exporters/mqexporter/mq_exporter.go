@@ -0,0 +1,118 @@
+package mqexporter
+
+import (
+	"context"
+	_ "embed" // used to embed config
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorand/indexer/conduit"
+	"github.com/algorand/indexer/conduit/plugins"
+	"github.com/algorand/indexer/conduit/plugins/exporters"
+	"github.com/algorand/indexer/data"
+	"github.com/algorand/indexer/encoding"
+)
+
+const exporterName = "message_queue"
+
+// publisher is the narrow interface the two supported backends implement,
+// so Receive doesn't need to know whether it's talking to NATS or AMQP.
+type publisher interface {
+	Publish(body []byte) error
+	Close() error
+}
+
+// mqExporter publishes one message per block (see blockMessage) to a NATS
+// JetStream or AMQP broker, for shops that want a durable queue without
+// running Kafka.
+type mqExporter struct {
+	round   uint64
+	cfg     Config
+	logger  *logrus.Logger
+	publish publisher
+}
+
+//go:embed sample.yaml
+var sampleConfig string
+
+var metadata = conduit.Metadata{
+	Name:         exporterName,
+	Description:  "Exporter that publishes blocks to a NATS JetStream or AMQP broker.",
+	Deprecated:   false,
+	SampleConfig: sampleConfig,
+}
+
+func (exp *mqExporter) Metadata() conduit.Metadata {
+	return metadata
+}
+
+func (exp *mqExporter) Init(_ context.Context, initProvider data.InitProvider, cfg plugins.PluginConfig, logger *logrus.Logger) error {
+	exp.logger = logger
+	if err := cfg.UnmarshalConfig(&exp.cfg); err != nil {
+		return fmt.Errorf("message_queue exporter Init(): unmarshal config err: %w", err)
+	}
+
+	pub, err := newPublisher(exp.cfg)
+	if err != nil {
+		return fmt.Errorf("message_queue exporter Init(): %w", err)
+	}
+	exp.publish = pub
+
+	exp.round = uint64(initProvider.NextDBRound())
+	return nil
+}
+
+func (exp *mqExporter) Config() string {
+	ret, _ := yaml.Marshal(exp.cfg)
+	return string(ret)
+}
+
+func (exp *mqExporter) Close() error {
+	if exp.publish == nil {
+		return nil
+	}
+	return exp.publish.Close()
+}
+
+// blockMessage is the JSON payload published for each block: the round, and
+// under "block" the same data.BlockData that file_writer writes as the whole
+// of each block file. A message is therefore not itself in file_writer's
+// format; its "block" field is.
+type blockMessage struct {
+	Round uint64         `codec:"round"`
+	Block data.BlockData `codec:"block"`
+}
+
+func (exp *mqExporter) Receive(exportData data.BlockData) error {
+	if exportData.Round() != exp.round {
+		return fmt.Errorf("message_queue exporter Receive(): wrong block: received round %d, expected round %d", exportData.Round(), exp.round)
+	}
+
+	body := encoding.Marshal(blockMessage{Round: exportData.Round(), Block: exportData})
+	if err := exp.publish.Publish(body); err != nil {
+		return fmt.Errorf("message_queue exporter Receive(): %w", err)
+	}
+
+	exp.round = exportData.Round() + 1
+	return nil
+}
+
+// newPublisher validates cfg and dials the backend it selects.
+func newPublisher(cfg Config) (publisher, error) {
+	switch cfg.Backend {
+	case BackendNats:
+		return newNatsPublisher(cfg)
+	case BackendAMQP:
+		return newAMQPPublisher(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported backend %q: must be %q or %q", cfg.Backend, BackendNats, BackendAMQP)
+	}
+}
+
+func init() {
+	exporters.Register(exporterName, exporters.ExporterConstructorFunc(func() exporters.Exporter {
+		return &mqExporter{}
+	}))
+}
@@ -0,0 +1,79 @@
+package mqexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/bookkeeping"
+
+	"github.com/algorand/indexer/data"
+)
+
+type fakePublisher struct {
+	bodies [][]byte
+	closed bool
+}
+
+func (p *fakePublisher) Publish(body []byte) error {
+	p.bodies = append(p.bodies, body)
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestMQExporterMetadata(t *testing.T) {
+	exp := &mqExporter{}
+	meta := exp.Metadata()
+	assert.Equal(t, exporterName, meta.Name)
+}
+
+func TestNewPublisherRejectsUnknownBackend(t *testing.T) {
+	_, err := newPublisher(Config{Backend: "rocketmq"})
+	assert.ErrorContains(t, err, "unsupported backend")
+}
+
+func TestNewPublisherRequiresNatsSubjectAndStream(t *testing.T) {
+	_, err := newPublisher(Config{Backend: BackendNats, URL: "nats://localhost:4222"})
+	assert.ErrorContains(t, err, "subject is required")
+
+	_, err = newPublisher(Config{Backend: BackendNats, URL: "nats://localhost:4222", Subject: "blocks"})
+	assert.ErrorContains(t, err, "stream is required")
+}
+
+func TestNewPublisherRequiresAMQPRoutingKey(t *testing.T) {
+	_, err := newPublisher(Config{Backend: BackendAMQP, URL: "amqp://localhost:5672"})
+	assert.ErrorContains(t, err, "routing-key is required")
+}
+
+func TestMQExporterReceivePublishesAndAdvancesRound(t *testing.T) {
+	fake := &fakePublisher{}
+	exp := &mqExporter{publish: fake, round: 5}
+
+	block := data.BlockData{BlockHeader: bookkeeping.BlockHeader{Round: 5}}
+	require.NoError(t, exp.Receive(block))
+
+	assert.Len(t, fake.bodies, 1)
+	assert.EqualValues(t, 6, exp.round)
+}
+
+func TestMQExporterReceiveRejectsWrongRound(t *testing.T) {
+	fake := &fakePublisher{}
+	exp := &mqExporter{publish: fake, round: 5}
+
+	block := data.BlockData{BlockHeader: bookkeeping.BlockHeader{Round: 9}}
+	err := exp.Receive(block)
+	assert.ErrorContains(t, err, "wrong block")
+	assert.Empty(t, fake.bodies)
+}
+
+func TestMQExporterCloseClosesPublisher(t *testing.T) {
+	fake := &fakePublisher{}
+	exp := &mqExporter{publish: fake}
+	require.NoError(t, exp.Close())
+	assert.True(t, fake.closed)
+}
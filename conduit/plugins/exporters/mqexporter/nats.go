@@ -0,0 +1,58 @@
+package mqexporter
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsPublisher publishes to a JetStream subject, creating the backing
+// stream on first connect if it doesn't already exist.
+type natsPublisher struct {
+	cfg  Config
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+func newNatsPublisher(cfg Config) (*natsPublisher, error) {
+	if cfg.Subject == "" {
+		return nil, fmt.Errorf("subject is required for backend %q", BackendNats)
+	}
+	if cfg.Stream == "" {
+		return nil, fmt.Errorf("stream is required for backend %q", BackendNats)
+	}
+
+	conn, err := nats.Connect(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", cfg.URL, err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not get JetStream context: %w", err)
+	}
+
+	if _, err := js.StreamInfo(cfg.Stream); err != nil {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     cfg.Stream,
+			Subjects: []string{cfg.Subject},
+		})
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not create stream %s: %w", cfg.Stream, err)
+		}
+	}
+
+	return &natsPublisher{cfg: cfg, conn: conn, js: js}, nil
+}
+
+func (p *natsPublisher) Publish(body []byte) error {
+	_, err := p.js.Publish(p.cfg.Subject, body)
+	return err
+}
+
+func (p *natsPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
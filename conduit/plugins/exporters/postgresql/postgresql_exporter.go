@@ -65,6 +65,7 @@ func (exp *postgresqlExporter) Init(ctx context.Context, initProvider data.InitP
 	var opts idb.IndexerDbOptions
 	opts.MaxConn = exp.cfg.MaxConn
 	opts.ReadOnly = false
+	opts.DryRun = exp.cfg.DryRun
 
 	// for some reason when ConnectionString is empty, it's automatically
 	// connecting to a local instance that's running.
@@ -92,7 +93,7 @@ func (exp *postgresqlExporter) Init(ctx context.Context, initProvider data.InitP
 	exp.round = uint64(initProvider.NextDBRound())
 
 	// if data pruning is enabled
-	if !exp.cfg.Test && exp.cfg.Delete.Rounds > 0 {
+	if !exp.cfg.Test && !exp.cfg.DryRun && exp.cfg.Delete.Rounds > 0 {
 		exp.dm = util.MakeDataManager(exp.ctx, &exp.cfg.Delete, exp.db, logger)
 		exp.wg.Add(1)
 		go exp.dm.DeleteLoop(&exp.wg, &exp.round)
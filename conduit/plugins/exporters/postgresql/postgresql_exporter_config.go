@@ -18,6 +18,10 @@ type ExporterConfig struct {
 	// The test flag will replace an actual DB connection being created via the connection string,
 	// with a mock DB for unit testing.
 	Test bool `yaml:"test"`
+	// DryRun connects to the database normally, but rolls back every write
+	// instead of committing it, so the importer can be soak-tested against
+	// live traffic without mutating the database.
+	DryRun bool `yaml:"dry-run"`
 	// Delete has the configuration for data pruning.
 	Delete util.PruneConfigurations `yaml:"delete-task"`
 }
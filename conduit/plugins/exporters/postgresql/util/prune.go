@@ -2,12 +2,16 @@ package util
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres"
 )
 
 // Interval determines how often to delete data
@@ -19,6 +23,10 @@ const (
 	d                 = 2 * time.Second
 )
 
+// pruneJobName identifies this exporter's pruning task in the job table, for
+// callers of idb/postgres's job framework (e.g. the admin API).
+const pruneJobName = "prune"
+
 // PruneConfigurations contains the configurations for data pruning
 type PruneConfigurations struct {
 	// Rounds to keep
@@ -33,12 +41,26 @@ type DataManager interface {
 	DeleteLoop(*sync.WaitGroup, *uint64)
 }
 
+// jobAwareDB is implemented by idb.IndexerDb backends that support the
+// generic background job framework (currently only postgres.IndexerDb).
+// When db satisfies it, deleteTransactions leases the prune job before
+// running, so its status is visible (and cancellable) through the admin
+// API, and concurrently running exporters don't race to prune the same
+// database.
+type jobAwareDB interface {
+	ClaimJob(ctx context.Context, name, kind, owner string, lease time.Duration) (postgres.Job, bool, error)
+	HeartbeatJob(ctx context.Context, name, owner string, lease time.Duration, progress json.RawMessage) (postgres.JobStatus, error)
+	FinishJob(ctx context.Context, name, owner string) error
+	FailJob(ctx context.Context, name, owner string, jobErr error) error
+}
+
 type postgresql struct {
 	config   *PruneConfigurations
 	db       idb.IndexerDb
 	logger   *logrus.Logger
 	ctx      context.Context
 	duration time.Duration
+	owner    string
 }
 
 // MakeDataManager initializes resources need for removing data from data source
@@ -50,11 +72,50 @@ func MakeDataManager(ctx context.Context, cfg *PruneConfigurations, db idb.Index
 		logger:   logger,
 		ctx:      ctx,
 		duration: d,
+		owner:    fmt.Sprintf("postgresql-exporter-%d", os.Getpid()),
 	}
 
 	return dm
 }
 
+// deleteTransactions runs DeleteTransactions for the given keep round,
+// wrapped in the background job framework when db supports it: it claims
+// the prune job first so a concurrently running exporter doesn't prune the
+// same database at the same time, and records the job as done or failed
+// once DeleteTransactions returns.
+func (p *postgresql) deleteTransactions(keep uint64) error {
+	jobDB, ok := p.db.(jobAwareDB)
+	if !ok {
+		return p.db.DeleteTransactions(p.ctx, keep)
+	}
+
+	lease := p.duration * 10
+	_, claimed, err := jobDB.ClaimJob(p.ctx, pruneJobName, postgres.JobKindPrune, p.owner, lease)
+	if err != nil {
+		return fmt.Errorf("deleteTransactions(): claim err %w", err)
+	}
+	if !claimed {
+		p.logger.Infof("deleteTransactions(): prune job is leased by another owner, skipping this round")
+		return nil
+	}
+
+	if err := p.db.DeleteTransactions(p.ctx, keep); err != nil {
+		if failErr := jobDB.FailJob(p.ctx, pruneJobName, p.owner, err); failErr != nil {
+			p.logger.Warnf("deleteTransactions(): failed to record job failure: %v", failErr)
+		}
+		return err
+	}
+
+	progress, _ := json.Marshal(map[string]uint64{"kept_through_round": keep})
+	if _, err := jobDB.HeartbeatJob(p.ctx, pruneJobName, p.owner, lease, progress); err != nil {
+		p.logger.Warnf("deleteTransactions(): failed to record job progress: %v", err)
+	}
+	if err := jobDB.FinishJob(p.ctx, pruneJobName, p.owner); err != nil {
+		p.logger.Warnf("deleteTransactions(): failed to record job completion: %v", err)
+	}
+	return nil
+}
+
 // DeleteLoop removes data from the txn table in Postgres DB
 func (p *postgresql) DeleteLoop(wg *sync.WaitGroup, nextRound *uint64) {
 
@@ -71,7 +132,7 @@ func (p *postgresql) DeleteLoop(wg *sync.WaitGroup, nextRound *uint64) {
 			keep := currentRound - p.config.Rounds
 			if p.config.Interval == once {
 				if currentRound > p.config.Rounds {
-					err := p.db.DeleteTransactions(p.ctx, keep)
+					err := p.deleteTransactions(keep)
 					if err != nil {
 						p.logger.Warnf("MakeDataManager(): data pruning err: %v", err)
 					}
@@ -80,7 +141,7 @@ func (p *postgresql) DeleteLoop(wg *sync.WaitGroup, nextRound *uint64) {
 			} else if p.config.Interval > disabled {
 				// *nextRound should increment as exporter receives new block
 				if currentRound > p.config.Rounds && currentRound-round >= uint64(p.config.Interval) {
-					err := p.db.DeleteTransactions(p.ctx, keep)
+					err := p.deleteTransactions(keep)
 					if err != nil {
 						p.logger.Warnf("DeleteLoop(): data pruning err: %v", err)
 						return
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/algorand/indexer/conduit/plugins/processors/filterprocessor/expression"
+)
+
+// SubConfig is a single field match, same shape as filter_processor's: the
+// tag identifies the transaction field (e.g. "txn.snd", "txn.aamt"),
+// expression-type picks exact/regex/numeric comparison, and expression is
+// the value being matched against.
+type SubConfig struct {
+	// FilterTag the tag of the struct field to match, e.g. "txn.rcv".
+	FilterTag string `yaml:"tag"`
+	// ExpressionType the kind of match to apply (exact, regex, greater-than, etc).
+	ExpressionType expression.FilterType `yaml:"expression-type"`
+	// Expression the value to match against.
+	Expression string `yaml:"expression"`
+}
+
+// Config is the configuration for the webhook exporter.
+type Config struct {
+	// URLs are the webhook endpoints a matching transaction is POSTed to.
+	URLs []string `yaml:"urls"`
+
+	// Filters are a list of boolean expressions, keyed by "any"/"all"/"none",
+	// applied the same way as filter_processor's. A transaction is notified
+	// if it passes every filter in the list.
+	Filters []map[string][]SubConfig `yaml:"filters"`
+
+	// Timeout bounds a single webhook POST attempt. Default 5s.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// MaxRetries is how many additional attempts are made after a failed
+	// POST before the notification is given up on and dead-lettered.
+	// Default 3.
+	MaxRetries int `yaml:"max-retries"`
+
+	// RetryInterval is the delay between retry attempts. Default 1s.
+	RetryInterval time.Duration `yaml:"retry-interval"`
+
+	// DeadLetterFile is an optional path to a file that permanently failed
+	// notifications are appended to, one JSON object per line. If unset,
+	// failures are only logged.
+	DeadLetterFile string `yaml:"dead-letter-file"`
+}
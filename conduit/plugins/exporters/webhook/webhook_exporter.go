@@ -0,0 +1,251 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	_ "embed" // used to embed config
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorand/go-algorand/data/transactions"
+
+	"github.com/algorand/indexer/conduit"
+	"github.com/algorand/indexer/conduit/plugins"
+	"github.com/algorand/indexer/conduit/plugins/exporters"
+	"github.com/algorand/indexer/conduit/plugins/processors/filterprocessor/expression"
+	"github.com/algorand/indexer/conduit/plugins/processors/filterprocessor/fields"
+	"github.com/algorand/indexer/data"
+	"github.com/algorand/indexer/encoding"
+)
+
+const exporterName = "webhook"
+
+const (
+	defaultTimeout       = 5 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryInterval = time.Second
+)
+
+// webhookExporter POSTs a JSON payload to configured webhook URLs for each
+// transaction that matches its filters, so alerting on specific addresses,
+// assets, apps, or amounts doesn't require polling the API.
+type webhookExporter struct {
+	round   uint64
+	cfg     Config
+	logger  *logrus.Logger
+	client  *http.Client
+	filters []fields.Filter
+
+	deadLetterMu sync.Mutex
+	deadLetter   *os.File
+}
+
+//go:embed sample.yaml
+var sampleConfig string
+
+var metadata = conduit.Metadata{
+	Name:         exporterName,
+	Description:  "Exporter that POSTs matching transactions to webhook URLs.",
+	Deprecated:   false,
+	SampleConfig: sampleConfig,
+}
+
+func (exp *webhookExporter) Metadata() conduit.Metadata {
+	return metadata
+}
+
+// buildFilters turns the configured []map[string][]SubConfig into the same
+// fields.Filter list filter_processor builds, so "any"/"all"/"none"
+// semantics and supported tags/expression-types stay identical between the
+// two plugins.
+func buildFilters(configMaps []map[string][]SubConfig) ([]fields.Filter, error) {
+	var filterList []fields.Filter
+
+	for _, configMap := range configMaps {
+		if len(configMap) != 1 {
+			return nil, fmt.Errorf("illegal filter formation: expected exactly one of any/all/none, got %d", len(configMap))
+		}
+
+		for key, subConfigs := range configMap {
+			if !fields.ValidFieldOperation(key) {
+				return nil, fmt.Errorf("filter key was not a valid value: %s", key)
+			}
+
+			var searcherList []*fields.Searcher
+			for _, subConfig := range subConfigs {
+				t, err := fields.SignedTxnFunc(subConfig.FilterTag, &transactions.SignedTxnInBlock{})
+				if err != nil {
+					return nil, err
+				}
+
+				// SignedTxnFunc returns a pointer underneath the interface{}.
+				targetKind := reflect.TypeOf(t).Elem().Kind()
+
+				exp, err := expression.MakeExpression(subConfig.ExpressionType, subConfig.Expression, targetKind)
+				if err != nil {
+					return nil, fmt.Errorf("could not make expression %q for tag %s: %w", subConfig.Expression, subConfig.FilterTag, err)
+				}
+
+				searcher, err := fields.MakeFieldSearcher(exp, subConfig.ExpressionType, subConfig.FilterTag)
+				if err != nil {
+					return nil, fmt.Errorf("error making field searcher: %w", err)
+				}
+
+				searcherList = append(searcherList, searcher)
+			}
+
+			filterList = append(filterList, fields.Filter{
+				Op:        fields.Operation(key),
+				Searchers: searcherList,
+			})
+		}
+	}
+
+	return filterList, nil
+}
+
+func (exp *webhookExporter) Init(_ context.Context, initProvider data.InitProvider, cfg plugins.PluginConfig, logger *logrus.Logger) error {
+	exp.logger = logger
+	if err := cfg.UnmarshalConfig(&exp.cfg); err != nil {
+		return fmt.Errorf("webhook exporter Init(): unmarshal config err: %w", err)
+	}
+	if len(exp.cfg.URLs) == 0 {
+		return fmt.Errorf("webhook exporter Init(): at least one url is required")
+	}
+
+	if exp.cfg.Timeout <= 0 {
+		exp.cfg.Timeout = defaultTimeout
+	}
+	if exp.cfg.MaxRetries <= 0 {
+		exp.cfg.MaxRetries = defaultMaxRetries
+	}
+	if exp.cfg.RetryInterval <= 0 {
+		exp.cfg.RetryInterval = defaultRetryInterval
+	}
+
+	filterList, err := buildFilters(exp.cfg.Filters)
+	if err != nil {
+		return fmt.Errorf("webhook exporter Init(): %w", err)
+	}
+	exp.filters = filterList
+
+	exp.client = &http.Client{Timeout: exp.cfg.Timeout}
+
+	if exp.cfg.DeadLetterFile != "" {
+		f, err := os.OpenFile(exp.cfg.DeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("webhook exporter Init(): could not open dead letter file: %w", err)
+		}
+		exp.deadLetter = f
+	}
+
+	exp.round = uint64(initProvider.NextDBRound())
+	return nil
+}
+
+func (exp *webhookExporter) Config() string {
+	ret, _ := yaml.Marshal(exp.cfg)
+	return string(ret)
+}
+
+func (exp *webhookExporter) Close() error {
+	if exp.deadLetter != nil {
+		return exp.deadLetter.Close()
+	}
+	return nil
+}
+
+// webhookPayload is what gets POSTed for each matching transaction.
+type webhookPayload struct {
+	Round uint64                        `codec:"round"`
+	Txn   transactions.SignedTxnInBlock `codec:"txn"`
+}
+
+func (exp *webhookExporter) Receive(exportData data.BlockData) error {
+	if exportData.Round() != exp.round {
+		return fmt.Errorf("webhook exporter Receive(): wrong block: received round %d, expected round %d", exportData.Round(), exp.round)
+	}
+
+	matched := exportData
+	for _, f := range exp.filters {
+		var err error
+		matched, err = f.SearchAndFilter(matched)
+		if err != nil {
+			return fmt.Errorf("webhook exporter Receive(): %w", err)
+		}
+	}
+
+	for _, txn := range matched.Payset {
+		body := encoding.Marshal(webhookPayload{Round: exportData.Round(), Txn: txn})
+		for _, url := range exp.cfg.URLs {
+			if err := exp.postWithRetry(url, body); err != nil {
+				exp.writeDeadLetter(url, body, err)
+			}
+		}
+	}
+
+	exp.round = exportData.Round() + 1
+	return nil
+}
+
+// postWithRetry POSTs body to url, retrying up to cfg.MaxRetries times with
+// a fixed delay between attempts. It returns the last error if every
+// attempt fails.
+func (exp *webhookExporter) postWithRetry(url string, body []byte) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= exp.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(exp.cfg.RetryInterval)
+		}
+
+		resp, err := exp.client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+	}
+
+	return lastErr
+}
+
+// writeDeadLetter logs a permanently failed notification and, if a dead
+// letter file is configured, appends the payload and cause to it so the
+// notification isn't silently lost.
+func (exp *webhookExporter) writeDeadLetter(url string, body []byte, cause error) {
+	exp.logger.Errorf("webhook exporter: giving up on notifying %s: %v", url, cause)
+
+	if exp.deadLetter == nil {
+		return
+	}
+
+	entry := encoding.Marshal(struct {
+		URL   string `codec:"url"`
+		Error string `codec:"error"`
+		Body  []byte `codec:"body"`
+	}{URL: url, Error: cause.Error(), Body: body})
+
+	exp.deadLetterMu.Lock()
+	defer exp.deadLetterMu.Unlock()
+	if _, err := exp.deadLetter.Write(append(entry, '\n')); err != nil {
+		exp.logger.Errorf("webhook exporter: could not write dead letter entry: %v", err)
+	}
+}
+
+func init() {
+	exporters.Register(exporterName, exporters.ExporterConstructorFunc(func() exporters.Exporter {
+		return &webhookExporter{}
+	}))
+}
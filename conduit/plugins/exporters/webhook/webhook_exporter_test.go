@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+
+	"github.com/algorand/indexer/conduit/plugins"
+	"github.com/algorand/indexer/conduit/plugins/exporters"
+	"github.com/algorand/indexer/data"
+	testutil "github.com/algorand/indexer/util/test"
+)
+
+func TestWebhookExporterMetadata(t *testing.T) {
+	we := &webhookExporter{}
+	meta := we.Metadata()
+	assert.Equal(t, exporterName, meta.Name)
+}
+
+func TestWebhookExporterInitRequiresURL(t *testing.T) {
+	we, err := exporters.ExporterBuilderByName(exporterName)
+	require.NoError(t, err)
+
+	err = we.New().Init(context.Background(), testutil.MockedInitProvider(nil), plugins.MakePluginConfig("urls: []"), logrus.New())
+	assert.ErrorContains(t, err, "at least one url is required")
+}
+
+func TestWebhookExporterReceiveNotifiesMatchingTxnOnly(t *testing.T) {
+	var posts int32
+	var lastBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		lastBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	matchAddr := basics.Address{1}
+	otherAddr := basics.Address{2}
+
+	cfgStr := `
+urls:
+  - "` + server.URL + `"
+filters:
+  - any:
+    - tag: txn.rcv
+      expression-type: exact
+      expression: "` + matchAddr.String() + `"
+`
+
+	builder, err := exporters.ExporterBuilderByName(exporterName)
+	require.NoError(t, err)
+	we := builder.New()
+	err = we.Init(context.Background(), testutil.MockedInitProvider(nil), plugins.MakePluginConfig(cfgStr), logrus.New())
+	require.NoError(t, err)
+	defer we.Close()
+
+	block := data.BlockData{
+		BlockHeader: bookkeeping.BlockHeader{Round: 0},
+		Payset: []transactions.SignedTxnInBlock{
+			{
+				SignedTxnWithAD: transactions.SignedTxnWithAD{
+					SignedTxn: transactions.SignedTxn{
+						Txn: transactions.Transaction{
+							PaymentTxnFields: transactions.PaymentTxnFields{Receiver: matchAddr},
+						},
+					},
+				},
+			},
+			{
+				SignedTxnWithAD: transactions.SignedTxnWithAD{
+					SignedTxn: transactions.SignedTxn{
+						Txn: transactions.Transaction{
+							PaymentTxnFields: transactions.PaymentTxnFields{Receiver: otherAddr},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, we.Receive(block))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&posts))
+	assert.Contains(t, string(lastBody), "round")
+}
+
+func TestWebhookExporterDeadLettersAfterRetriesExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	addr := basics.Address{1}
+	cfgStr := `
+urls:
+  - "` + server.URL + `"
+max-retries: 0
+retry-interval: 1ms
+dead-letter-file: "` + deadLetterFile + `"
+filters:
+  - any:
+    - tag: txn.rcv
+      expression-type: exact
+      expression: "` + addr.String() + `"
+`
+
+	builder, err := exporters.ExporterBuilderByName(exporterName)
+	require.NoError(t, err)
+	we := builder.New()
+	err = we.Init(context.Background(), testutil.MockedInitProvider(nil), plugins.MakePluginConfig(cfgStr), logrus.New())
+	require.NoError(t, err)
+	defer we.Close()
+
+	block := data.BlockData{
+		BlockHeader: bookkeeping.BlockHeader{Round: 0},
+		Payset: []transactions.SignedTxnInBlock{
+			{
+				SignedTxnWithAD: transactions.SignedTxnWithAD{
+					SignedTxn: transactions.SignedTxn{
+						Txn: transactions.Transaction{
+							PaymentTxnFields: transactions.PaymentTxnFields{Receiver: addr},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, we.Receive(block))
+	require.NoError(t, we.Close())
+
+	contents, err := os.ReadFile(deadLetterFile)
+	require.NoError(t, err)
+	require.NotEmpty(t, contents)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents[:len(contents)-1], &entry))
+	assert.Equal(t, server.URL, entry["url"])
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed" // used to embed config
 	"fmt"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +25,19 @@ import (
 
 const importerName = "algod"
 
+// encodedBlockCertPool reuses *rpcs.EncodedBlockCert instances across
+// GetBlock calls instead of allocating one per round, since msgpack
+// decoding a large block's struct/map layout is a significant source of
+// GC pressure during catchup. Each pooled instance is reset to its zero
+// value before reuse, so the decoder always allocates fresh backing
+// storage for the round it's decoding rather than reusing (and silently
+// corrupting) a previous round's data that the caller may still hold.
+var encodedBlockCertPool = sync.Pool{
+	New: func() interface{} {
+		return new(rpcs.EncodedBlockCert)
+	},
+}
+
 type algodImporter struct {
 	aclient *algod.Client
 	logger  *logrus.Logger
@@ -132,14 +146,17 @@ func (algodImp *algodImporter) GetBlock(rnd uint64) (data.BlockData, error) {
 		if err != nil {
 			return blk, err
 		}
-		tmpBlk := new(rpcs.EncodedBlockCert)
+		tmpBlk := encodedBlockCertPool.Get().(*rpcs.EncodedBlockCert)
+		*tmpBlk = rpcs.EncodedBlockCert{}
 		err = protocol.Decode(blockbytes, tmpBlk)
 
+		cert := tmpBlk.Certificate
 		blk = data.BlockData{
 			BlockHeader: tmpBlk.Block.BlockHeader,
 			Payset:      tmpBlk.Block.Payset,
-			Certificate: &tmpBlk.Certificate,
+			Certificate: &cert,
 		}
+		encodedBlockCertPool.Put(tmpBlk)
 		return blk, err
 	}
 	algodImp.logger.Error("GetBlock finished retries without fetching a block.")
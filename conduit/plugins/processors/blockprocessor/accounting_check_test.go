@@ -0,0 +1,32 @@
+package blockprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+func TestCheckApplyDataMatch(t *testing.T) {
+	makePayset := func(closingAmount uint64) transactions.Payset {
+		return transactions.Payset{
+			{
+				SignedTxnWithAD: transactions.SignedTxnWithAD{
+					ApplyData: transactions.ApplyData{
+						ClosingAmount: basics.MicroAlgos{Raw: closingAmount},
+					},
+				},
+			},
+		}
+	}
+
+	assert.NoError(t, checkApplyDataMatch(makePayset(5), makePayset(5)))
+
+	err := checkApplyDataMatch(makePayset(5), makePayset(6))
+	assert.ErrorContains(t, err, "transaction 0")
+
+	err = checkApplyDataMatch(makePayset(5), transactions.Payset{})
+	assert.ErrorContains(t, err, "payset length mismatch")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed" // used to embed config
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,6 +26,7 @@ import (
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
 )
 
@@ -50,6 +52,9 @@ type blockProcessor struct {
 	ledger  *ledger.Ledger
 	logger  *log.Logger
 
+	// creatorCache memoizes asset/app creator lookups across rounds.
+	creatorCache *indexerledger.CreatorCache
+
 	cfg Config
 	ctx context.Context
 
@@ -59,6 +64,13 @@ type blockProcessor struct {
 	// lastValidatedBlockRound is the round at which to add the last validated block
 	lastValidatedBlockRound       basics.Round
 	lastValidatedBlockCertificate agreement.Certificate
+
+	// prevTotals is the account totals left by the previous round's
+	// accounting, used by CheckAccountingInvariants to confirm the
+	// circulating algo supply didn't change. Left unset until the first
+	// round after genesis has been processed.
+	prevTotals     ledgercore.AccountTotals
+	havePrevTotals bool
 }
 
 //go:embed sample.yaml
@@ -107,6 +119,7 @@ func (proc *blockProcessor) Init(ctx context.Context, initProvider data.InitProv
 		return fmt.Errorf("ledger was created with nil pointer")
 	}
 	proc.ledger = l
+	proc.creatorCache = indexerledger.MakeCreatorCache()
 
 	if uint64(l.Latest()) > round {
 		return fmt.Errorf("the ledger cache is ahead of the required round (%d > %d) and must be re-initialized", l.Latest(), round)
@@ -140,7 +153,7 @@ func (proc *blockProcessor) extractValidatedBlockAndPayset(blockCert *rpcs.Encod
 	protoChanged := !proto.EnableAssetCloseAmount
 	proto.EnableAssetCloseAmount = true
 
-	ledgerForEval := indexerledger.MakeLedgerForEvaluator(proc.ledger)
+	ledgerForEval := indexerledger.MakeLedgerForEvaluatorWithCreatorCache(proc.ledger, proc.creatorCache)
 
 	resources, err := prepareEvalResources(&ledgerForEval, &blockCert.Block)
 	if err != nil {
@@ -153,6 +166,33 @@ func (proc *blockProcessor) extractValidatedBlockAndPayset(blockCert *rpcs.Encod
 		return vb, transactions.Payset{}, fmt.Errorf("eval err: %w", err)
 	}
 	EvalTimeSeconds.Observe(time.Since(start).Seconds())
+	indexerledger.InvalidateDestroyed(proc.creatorCache, payset)
+
+	// protoChanged already tells us the recomputed ApplyData is expected to
+	// differ from the archival block (that's the whole point of the
+	// backfill), so only cross-check when the block's own ApplyData should
+	// already agree with what we just computed.
+	if proc.cfg.StrictAccounting && !protoChanged {
+		if err := checkApplyDataMatch(blockCert.Block.Payset, payset); err != nil {
+			proc.logger.Errorf("block %d accounting mismatch: %v", blockCert.Block.Round(), err)
+			if proc.cfg.HaltOnAccountingMismatch {
+				return vb, transactions.Payset{}, fmt.Errorf("extractValidatedBlockAndPayset() err: %w", err)
+			}
+		}
+	}
+
+	if proc.cfg.CheckAccountingInvariants {
+		if proc.havePrevTotals {
+			if err := checkAccountingInvariants(proc.prevTotals, delta.Totals, payset); err != nil {
+				proc.logger.Errorf("block %d accounting invariant violation: %v", blockCert.Block.Round(), err)
+				if proc.cfg.HaltOnInvariantViolation {
+					return vb, transactions.Payset{}, fmt.Errorf("extractValidatedBlockAndPayset() err: %w", err)
+				}
+			}
+		}
+		proc.prevTotals = delta.Totals
+		proc.havePrevTotals = true
+	}
 
 	// validated block
 	if protoChanged {
@@ -280,6 +320,45 @@ func (proc *blockProcessor) ProvideMetrics() []prometheus.Collector {
 	}
 }
 
+// checkApplyDataMatch reports an error describing the first transaction whose
+// ApplyData, trusted as-is from the archival block, doesn't match the
+// ApplyData recomputed by locally replaying the block through the evaluator.
+func checkApplyDataMatch(archival, recomputed transactions.Payset) error {
+	if len(archival) != len(recomputed) {
+		return fmt.Errorf("payset length mismatch: archival %d recomputed %d", len(archival), len(recomputed))
+	}
+	for i := range archival {
+		archivalAD := archival[i].SignedTxnWithAD.ApplyData
+		recomputedAD := recomputed[i].SignedTxnWithAD.ApplyData
+		if !reflect.DeepEqual(archivalAD, recomputedAD) {
+			return fmt.Errorf("transaction %d: archival ApplyData %+v != recomputed ApplyData %+v", i, archivalAD, recomputedAD)
+		}
+	}
+	return nil
+}
+
+// checkAccountingInvariants reports an error describing the first violation
+// found of an invariant that consensus itself is supposed to guarantee:
+// that the circulating algo supply carried over from the previous round's
+// totals is unchanged (fees and rewards only move algos between accounts
+// and the fee sink/rewards pool, they never create or destroy them), and
+// that every asset transfer's recorded closing amount is only non-zero
+// when the transaction actually closed the asset out to another account.
+func checkAccountingInvariants(prevTotals, totals ledgercore.AccountTotals, payset transactions.Payset) error {
+	if prevAll, all := prevTotals.All(), totals.All(); prevAll != all {
+		return fmt.Errorf("circulating algo supply changed from %d to %d microalgos", prevAll.Raw, all.Raw)
+	}
+
+	for i := range payset {
+		txn := payset[i].SignedTxnWithAD.SignedTxn.Txn
+		ad := payset[i].SignedTxnWithAD.ApplyData
+		if txn.Type == protocol.AssetTransferTx && ad.AssetClosingAmount != 0 && txn.AssetCloseTo.IsZero() {
+			return fmt.Errorf("transaction %d: asset closing amount %d recorded without a close-to address", i, ad.AssetClosingAmount)
+		}
+	}
+	return nil
+}
+
 // Preload all resources (account data, account resources, asset/app creators) for the
 // evaluator.
 func prepareEvalResources(l *indexerledger.LedgerForEvaluator, block *bookkeeping.Block) (ledger.EvalForIndexerResources, error) {
@@ -1,12 +1,74 @@
 package blockprocessor
 
+import "time"
+
 // Config configuration for a block processor
 type Config struct {
-	// Catchpoint to initialize the local ledger to
+	// Catchpoint to initialize the local ledger to. Only useful once the
+	// database has already imported rounds up through the catchpoint's
+	// round; see docs/LocalLedger.md.
 	Catchpoint string `yaml:"catchpoint"`
 
 	LedgerDir    string `yaml:"ledger-dir"`
 	AlgodDataDir string `yaml:"algod-data-dir"`
 	AlgodToken   string `yaml:"algod-token"`
 	AlgodAddr    string `yaml:"algod-addr"`
+
+	// BlockArchiveURL, if set, is the base URL of an object-store archive
+	// of raw blocks. It's consulted when algod is non-archival and
+	// reports a requested round missing, so re-importing old rounds
+	// doesn't require an archival node.
+	BlockArchiveURL string `yaml:"block-archive-url"`
+
+	// StrictAccounting, when true, cross-checks each transaction's
+	// ApplyData as recomputed by the local evaluator against the
+	// ApplyData already embedded in the archival block, logging any
+	// mismatch. The default, false, skips the extra per-transaction
+	// comparison, but every block is still fully evaluated either way:
+	// the local ledger only gains the incremental per-round account state
+	// that every later round's evaluation depends on as a side effect of
+	// running the evaluator, so there is no way to trust the archival
+	// block's ApplyData outright and skip recomputation altogether.
+	StrictAccounting bool `yaml:"strict-accounting"`
+
+	// HaltOnAccountingMismatch, when true, makes a StrictAccounting
+	// mismatch fail the round instead of just logging it, so operators
+	// can stop importing rather than persist data that didn't reconcile.
+	// Has no effect unless StrictAccounting is also set.
+	HaltOnAccountingMismatch bool `yaml:"halt-on-accounting-mismatch"`
+
+	// RetryMaxAttempts caps how many times the fetcher retries a failed
+	// fetch of a single round (on top of the first attempt) before giving
+	// up on it for that pass. Zero falls back to fetcher.DefaultRetryPolicy.
+	RetryMaxAttempts uint64 `yaml:"retry-max-attempts"`
+
+	// RetryBackoffBase is the delay before the fetcher's first retry of a
+	// failed algod request, doubling on each subsequent attempt up to
+	// RetryBackoffMax. Zero falls back to fetcher.DefaultRetryPolicy.
+	RetryBackoffBase time.Duration `yaml:"retry-backoff-base"`
+
+	// RetryBackoffMax caps how long the fetcher's backoff delay can grow
+	// to. Zero falls back to fetcher.DefaultRetryPolicy.
+	RetryBackoffMax time.Duration `yaml:"retry-backoff-max"`
+
+	// StallTimeout is how long algod may go without yielding a new block
+	// before the fetcher's circuit breaker reports it unhealthy. Zero
+	// falls back to fetcher.DefaultRetryPolicy.
+	StallTimeout time.Duration `yaml:"stall-timeout"`
+
+	// CheckAccountingInvariants, when true, verifies after each round's
+	// accounting that the invariants consensus itself is supposed to
+	// guarantee still hold: the circulating algo supply is unchanged from
+	// the previous round (fees and rewards only move algos between
+	// accounts and the fee sink/rewards pool, never create or destroy
+	// them), and every asset transfer's closing amount is only recorded
+	// alongside a close-to address. A violation indicates a bug in
+	// indexer's own accounting rather than a consensus failure, logged
+	// before the round is committed to the ledger.
+	CheckAccountingInvariants bool `yaml:"check-accounting-invariants"`
+
+	// HaltOnInvariantViolation, when true, makes a CheckAccountingInvariants
+	// violation fail the round instead of just logging it. Has no effect
+	// unless CheckAccountingInvariants is also set.
+	HaltOnInvariantViolation bool `yaml:"halt-on-invariant-violation"`
 }
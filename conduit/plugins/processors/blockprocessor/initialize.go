@@ -20,7 +20,24 @@ import (
 // IndexerDbOpts.
 // nextRound - next round to process after initializing.
 // catchpoint - if provided, attempt to use fast catchup.
+//
+// Catchpoint fast catchup only rebuilds this package's local ledger cache
+// (used to validate and evaluate blocks as they're processed) up to an
+// already-imported database round; it does not seed the database's own
+// account/asset/app tables. A catchpoint is therefore only useful once
+// nextDbRound is already past the catchpoint's round, e.g. after the data
+// directory was lost or this is a new replica of an already-imported
+// database. On a fresh database (nextDbRound == 0) there is nothing for the
+// catchpoint to validate against yet, so it's rejected outright rather than
+// silently ignored: a user expecting it to seed account state from round R
+// without first importing rounds 0..R would otherwise get full history
+// import anyway, with no indication that the catchpoint was never used.
 func InitializeLedger(ctx context.Context, logger *log.Logger, nextDbRound uint64, genesis bookkeeping.Genesis, config *Config) error {
+	if nextDbRound == 0 && config.Catchpoint != "" {
+		return fmt.Errorf("InitializeLedger() err: catchpoint %q was given but the database has no rounds imported yet; "+
+			"catchpoint fast catchup only accelerates re-initializing the local ledger cache against an already-imported "+
+			"database, it cannot bootstrap account/asset/app state for a fresh database (see docs/LocalLedger.md)", config.Catchpoint)
+	}
 	if nextDbRound > 0 {
 		if config.Catchpoint != "" {
 			round, _, err := ledgercore.ParseCatchpointLabel(config.Catchpoint)
@@ -154,5 +171,28 @@ func getFetcher(logger *log.Logger, config *Config) (fetcher.Fetcher, error) {
 	} else {
 		return nil, fmt.Errorf("InitializeLedgerFastCatchup() err: unable to create algod client")
 	}
+	if config.BlockArchiveURL != "" {
+		bot.SetBlockArchive(fetcher.MakeHTTPBlockArchive(config.BlockArchiveURL))
+	}
+	bot.SetRetryPolicy(retryPolicyFromConfig(config))
 	return bot, nil
 }
+
+// retryPolicyFromConfig builds a fetcher.RetryPolicy from config, falling
+// back field-by-field to fetcher.DefaultRetryPolicy for anything left unset.
+func retryPolicyFromConfig(config *Config) fetcher.RetryPolicy {
+	policy := fetcher.DefaultRetryPolicy
+	if config.RetryMaxAttempts != 0 {
+		policy.MaxRetries = config.RetryMaxAttempts
+	}
+	if config.RetryBackoffBase != 0 {
+		policy.BackoffBase = config.RetryBackoffBase
+	}
+	if config.RetryBackoffMax != 0 {
+		policy.BackoffMax = config.RetryBackoffMax
+	}
+	if config.StallTimeout != 0 {
+		policy.StallTimeout = config.StallTimeout
+	}
+	return policy
+}
@@ -0,0 +1,101 @@
+package eval
+
+import (
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// defaultCreatorCacheSize bounds the number of asset/app creators kept in
+// memory. Creators are tiny (an address plus a bool), so even a generous
+// size is cheap; this just keeps very long-lived daemons from retaining an
+// unbounded number of entries.
+const defaultCreatorCacheSize = 1_000_000
+
+// CreatorCache memoizes asset/app creator lookups across rounds, since the
+// same popular assets and apps are referenced by transactions in many
+// consecutive blocks. It is invalidated per-index whenever a round destroys
+// the asset or application, so a reused index (which the protocol forbids,
+// but we don't rely on that here) never serves a stale creator.
+type CreatorCache struct {
+	maxSize int
+	assets  map[basics.AssetIndex]ledger.FoundAddress
+	apps    map[basics.AppIndex]ledger.FoundAddress
+}
+
+// MakeCreatorCache creates an empty CreatorCache.
+func MakeCreatorCache() *CreatorCache {
+	return &CreatorCache{
+		maxSize: defaultCreatorCacheSize,
+		assets:  make(map[basics.AssetIndex]ledger.FoundAddress),
+		apps:    make(map[basics.AppIndex]ledger.FoundAddress),
+	}
+}
+
+func (c *CreatorCache) getAsset(index basics.AssetIndex) (ledger.FoundAddress, bool) {
+	addr, ok := c.assets[index]
+	return addr, ok
+}
+
+func (c *CreatorCache) putAsset(index basics.AssetIndex, addr ledger.FoundAddress) {
+	if len(c.assets) >= c.maxSize {
+		return
+	}
+	c.assets[index] = addr
+}
+
+func (c *CreatorCache) getApp(index basics.AppIndex) (ledger.FoundAddress, bool) {
+	addr, ok := c.apps[index]
+	return addr, ok
+}
+
+func (c *CreatorCache) putApp(index basics.AppIndex, addr ledger.FoundAddress) {
+	if len(c.apps) >= c.maxSize {
+		return
+	}
+	c.apps[index] = addr
+}
+
+// InvalidateAsset removes a cached asset creator, used when a round destroys
+// the asset.
+func (c *CreatorCache) InvalidateAsset(index basics.AssetIndex) {
+	delete(c.assets, index)
+}
+
+// InvalidateApp removes a cached app creator, used when a round destroys the
+// application.
+func (c *CreatorCache) InvalidateApp(index basics.AppIndex) {
+	delete(c.apps, index)
+}
+
+// InvalidateDestroyed scans a payset (including inner transactions) for
+// asset/app destroy operations and evicts their creators from the cache, so
+// a later reference to the (now nonexistent) creatable isn't served a stale
+// hit.
+func InvalidateDestroyed(cache *CreatorCache, payset transactions.Payset) {
+	for i := range payset {
+		invalidateDestroyedTxn(cache, &payset[i].SignedTxnWithAD)
+	}
+}
+
+func invalidateDestroyedTxn(cache *CreatorCache, stxnad *transactions.SignedTxnWithAD) {
+	txn := &stxnad.Txn
+
+	switch txn.Type {
+	case protocol.AssetConfigTx:
+		fields := &txn.AssetConfigTxnFields
+		if fields.ConfigAsset != 0 && fields.AssetParams == (basics.AssetParams{}) {
+			cache.InvalidateAsset(fields.ConfigAsset)
+		}
+	case protocol.ApplicationCallTx:
+		fields := &txn.ApplicationCallTxnFields
+		if fields.ApplicationID != 0 && fields.OnCompletion == transactions.DeleteApplicationOC {
+			cache.InvalidateApp(fields.ApplicationID)
+		}
+	}
+
+	for i := range stxnad.ApplyData.EvalDelta.InnerTxns {
+		invalidateDestroyedTxn(cache, &stxnad.ApplyData.EvalDelta.InnerTxns[i])
+	}
+}
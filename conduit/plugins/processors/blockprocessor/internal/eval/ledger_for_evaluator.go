@@ -21,6 +21,10 @@ import (
 // go-algorand ledger/evalindexer.go and is used for accounting.
 type LedgerForEvaluator struct {
 	Ledger *ledger.Ledger
+
+	// creators caches asset/app creator lookups across rounds. It is nil
+	// when caching is disabled.
+	creators *CreatorCache
 }
 
 // MakeLedgerForEvaluator creates a LedgerForEvaluator object.
@@ -31,6 +35,18 @@ func MakeLedgerForEvaluator(ld *ledger.Ledger) LedgerForEvaluator {
 	return l
 }
 
+// MakeLedgerForEvaluatorWithCreatorCache creates a LedgerForEvaluator object
+// whose GetAssetCreator/GetAppCreator calls are memoized in the provided
+// cache. Callers are responsible for invalidating the cache (via
+// InvalidateDestroyed) once a round's transactions have been evaluated.
+func MakeLedgerForEvaluatorWithCreatorCache(ld *ledger.Ledger, cache *CreatorCache) LedgerForEvaluator {
+	l := LedgerForEvaluator{
+		Ledger:   ld,
+		creators: cache,
+	}
+	return l
+}
+
 // Close shuts down LedgerForEvaluator.
 func (l *LedgerForEvaluator) Close() {
 	l.Ledger.Close()
@@ -104,19 +120,25 @@ func (l LedgerForEvaluator) LookupResources(input map[basics.Address]map[ledger.
 
 // GetAssetCreator is part of go-algorand's indexerLedgerForEval interface.
 func (l LedgerForEvaluator) GetAssetCreator(indices map[basics.AssetIndex]struct{}) (map[basics.AssetIndex]ledger.FoundAddress, error) {
-	indicesArr := make([]basics.AssetIndex, 0, len(indices))
+	res := make(map[basics.AssetIndex]ledger.FoundAddress, len(indices))
 	for index := range indices {
-		indicesArr = append(indicesArr, index)
-	}
+		if l.creators != nil {
+			if found, ok := l.creators.getAsset(index); ok {
+				res[index] = found
+				continue
+			}
+		}
 
-	res := make(map[basics.AssetIndex]ledger.FoundAddress, len(indices))
-	for _, index := range indicesArr {
 		cidx := basics.CreatableIndex(index)
 		address, exists, err := l.Ledger.GetCreator(cidx, basics.AssetCreatable)
 		if err != nil {
 			return nil, fmt.Errorf("GetAssetCreator() err: %w", err)
 		}
-		res[index] = ledger.FoundAddress{Address: address, Exists: exists}
+		found := ledger.FoundAddress{Address: address, Exists: exists}
+		res[index] = found
+		if l.creators != nil {
+			l.creators.putAsset(index, found)
+		}
 	}
 
 	return res, nil
@@ -124,19 +146,25 @@ func (l LedgerForEvaluator) GetAssetCreator(indices map[basics.AssetIndex]struct
 
 // GetAppCreator is part of go-algorand's indexerLedgerForEval interface.
 func (l LedgerForEvaluator) GetAppCreator(indices map[basics.AppIndex]struct{}) (map[basics.AppIndex]ledger.FoundAddress, error) {
-	indicesArr := make([]basics.AppIndex, 0, len(indices))
+	res := make(map[basics.AppIndex]ledger.FoundAddress, len(indices))
 	for index := range indices {
-		indicesArr = append(indicesArr, index)
-	}
+		if l.creators != nil {
+			if found, ok := l.creators.getApp(index); ok {
+				res[index] = found
+				continue
+			}
+		}
 
-	res := make(map[basics.AppIndex]ledger.FoundAddress, len(indices))
-	for _, index := range indicesArr {
 		cidx := basics.CreatableIndex(index)
 		address, exists, err := l.Ledger.GetCreatorForRound(l.Ledger.Latest(), cidx, basics.AppCreatable)
 		if err != nil {
 			return nil, fmt.Errorf("GetAppCreator() err: %w", err)
 		}
-		res[index] = ledger.FoundAddress{Address: address, Exists: exists}
+		found := ledger.FoundAddress{Address: address, Exists: exists}
+		res[index] = found
+		if l.creators != nil {
+			l.creators.putApp(index, found)
+		}
 	}
 
 	return res, nil
@@ -0,0 +1,35 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	type inner struct {
+		B []byte `codec:"b"`
+		U uint64 `codec:"u"`
+	}
+
+	x := inner{B: []byte{0xff, 0x00, 0x11}, U: 42}
+	buf := Marshal(x)
+
+	var y inner
+	require.NoError(t, Unmarshal(buf, &y))
+	assert.Equal(t, x, y)
+}
+
+func TestMarshalIsDeterministic(t *testing.T) {
+	type s struct {
+		B int `codec:"b"`
+		A int `codec:"a"`
+	}
+
+	buf1 := Marshal(s{A: 1, B: 2})
+	buf2 := Marshal(s{A: 1, B: 2})
+	assert.Equal(t, buf1, buf2)
+	// Canonical encoding sorts object keys regardless of struct field order.
+	assert.Equal(t, `{"a":1,"b":2}`, string(buf1))
+}
@@ -0,0 +1,73 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrBlockArchiveNotFound is returned by a BlockArchive when the requested
+// round isn't present in the archive either.
+var ErrBlockArchiveNotFound = errors.New("round not found in block archive")
+
+// BlockArchive fetches raw, msgpack-encoded blocks for rounds that are no
+// longer available from a non-archival algod. It's consulted by the fetcher
+// as a fallback when algod reports a round missing.
+type BlockArchive interface {
+	// FetchRawBlock returns the raw block bytes for round, in the same
+	// encoding as algod's BlockRaw endpoint. It returns
+	// ErrBlockArchiveNotFound if the archive doesn't have round either.
+	FetchRawBlock(ctx context.Context, round uint64) ([]byte, error)
+}
+
+// httpBlockArchive fetches raw blocks from an object store over plain HTTP
+// GET, e.g. a public or presigned S3/GCS bucket URL. Each round is expected
+// to be stored as a separate object named by its round number, under
+// baseURL.
+type httpBlockArchive struct {
+	baseURL string
+	client  *http.Client
+}
+
+// MakeHTTPBlockArchive returns a BlockArchive that fetches raw blocks from
+// an object store via HTTP GET requests of the form "<baseURL>/<round>".
+// This covers S3-compatible stores, since objects in a public or presigned
+// bucket are retrievable with a plain GET.
+func MakeHTTPBlockArchive(baseURL string) BlockArchive {
+	return &httpBlockArchive{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+	}
+}
+
+// FetchRawBlock is part of the BlockArchive interface.
+func (a *httpBlockArchive) FetchRawBlock(ctx context.Context, round uint64) ([]byte, error) {
+	url := a.baseURL + "/" + strconv.FormatUint(round, 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FetchRawBlock() request err: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("FetchRawBlock() err: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBlockArchiveNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("FetchRawBlock() unexpected status %d for round %d", resp.StatusCode, round)
+	}
+
+	blockbytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("FetchRawBlock() read err: %w", err)
+	}
+	return blockbytes, nil
+}
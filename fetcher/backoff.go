@@ -0,0 +1,70 @@
+package fetcher
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy controls how a Fetcher retries and backs off from algod
+// request failures, and when it gives up waiting for algod to recover and
+// reports it unhealthy.
+type RetryPolicy struct {
+	// MaxRetries caps the number of retries the fetcher makes after a failed
+	// attempt to fetch a single round before giving up on it for this pass,
+	// so a round gets at most MaxRetries+1 attempts. Zero means make a
+	// single attempt, with no retries.
+	MaxRetries uint64
+
+	// BackoffBase is the delay before the first retry. It doubles after
+	// each subsequent failed attempt, up to BackoffMax.
+	BackoffBase time.Duration
+
+	// BackoffMax caps how long a single backoff delay can grow to.
+	BackoffMax time.Duration
+
+	// StallTimeout is how long algod may go without successfully yielding
+	// a new block before the circuit breaker trips and the fetcher reports
+	// algod unhealthy. Zero disables the circuit breaker.
+	StallTimeout time.Duration
+}
+
+// DefaultRetryPolicy is used by ForDataDir and ForNetAndToken when no other
+// policy is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:   5,
+	BackoffBase:  1 * time.Second,
+	BackoffMax:   30 * time.Second,
+	StallTimeout: 5 * time.Minute,
+}
+
+// backoff returns the delay to sleep before retry attempt number `attempt`
+// (0-indexed), doubling BackoffBase each attempt and capping at BackoffMax.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BackoffBase <= 0 {
+		return 0
+	}
+	delay := p.BackoffBase
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.BackoffMax && p.BackoffMax > 0 {
+			return p.BackoffMax
+		}
+	}
+	if p.BackoffMax > 0 && delay > p.BackoffMax {
+		delay = p.BackoffMax
+	}
+	return delay
+}
+
+// sleep pauses for d, returning early with ctx.Err() if ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -27,8 +28,23 @@ type Fetcher interface {
 	SetBlockHandler(f func(context.Context, *rpcs.EncodedBlockCert) error)
 	SetNextRound(nextRound uint64)
 
+	// SetBlockArchive configures a fallback BlockArchive to consult when
+	// algod reports a round missing, so historical re-import doesn't
+	// require an archival node. Nil (the default) disables the fallback.
+	SetBlockArchive(archive BlockArchive)
+
+	// SetRetryPolicy configures how many times and how long the fetcher
+	// retries a failing algod request before backing off, and how long
+	// algod may stay unreachable before Healthy starts reporting false.
+	SetRetryPolicy(policy RetryPolicy)
+
 	// Error returns any error fetcher is currently experiencing.
 	Error() string
+
+	// Healthy reports false once the circuit breaker has tripped, i.e.
+	// algod has failed continuously for at least the configured
+	// StallTimeout. It's also exported as the algod_health metric.
+	Healthy() bool
 }
 
 type fetcherImpl struct {
@@ -40,12 +56,22 @@ type fetcherImpl struct {
 
 	nextRound uint64
 
+	// archive is consulted when algod returns NotFound for nextRound,
+	// e.g. because algod is non-archival and has discarded the round.
+	archive BlockArchive
+
 	failingSince time.Time
+	// mainLoopFailures counts the consecutive passes through mainLoop that
+	// ended in failure, used to grow the backoff between re-client attempts.
+	mainLoopFailures int
+
+	retryPolicy RetryPolicy
 
 	log *log.Logger
 
-	err   error // protected by `errmu`
-	errmu sync.Mutex
+	err       error // protected by `errmu`
+	unhealthy bool  // protected by `errmu`; circuit breaker tripped
+	errmu     sync.Mutex
 
 	// To improve performance, we fetch new blocks and call the block handler concurrently.
 	// This queue contains the blocks that have been fetched but haven't been given to
@@ -74,6 +100,36 @@ func (bot *fetcherImpl) setError(err error) {
 	bot.errmu.Unlock()
 }
 
+// Healthy is part of the Fetcher interface
+func (bot *fetcherImpl) Healthy() bool {
+	bot.errmu.Lock()
+	defer bot.errmu.Unlock()
+	return !bot.unhealthy
+}
+
+// setUnhealthy trips or resets the circuit breaker, keeping the
+// algod_health metric in sync with it.
+func (bot *fetcherImpl) setUnhealthy(unhealthy bool) {
+	bot.errmu.Lock()
+	changed := bot.unhealthy != unhealthy
+	bot.unhealthy = unhealthy
+	bot.errmu.Unlock()
+
+	if changed {
+		if unhealthy {
+			metrics.AlgodHealthGauge.Set(0)
+			bot.log.Errorf("algod circuit breaker tripped after %s of continuous failures", bot.retryPolicy.StallTimeout)
+		} else {
+			metrics.AlgodHealthGauge.Set(1)
+		}
+	}
+}
+
+// SetRetryPolicy is part of the Fetcher interface
+func (bot *fetcherImpl) SetRetryPolicy(policy RetryPolicy) {
+	bot.retryPolicy = policy
+}
+
 func (bot *fetcherImpl) processQueue(ctx context.Context) error {
 	for {
 		select {
@@ -124,6 +180,31 @@ func (bot *fetcherImpl) catchupLoop(ctx context.Context) error {
 			if ctx.Err() != nil {
 				return fmt.Errorf("catchupLoop() fetch err: %w", err)
 			}
+
+			// algod may have returned 404 either because the round doesn't
+			// exist yet (we've caught up) or because it's non-archival and
+			// has discarded an old round. Try the archive before assuming
+			// the former.
+			if bot.archive != nil {
+				var archiveErr error
+				blockbytes, archiveErr = bot.archive.FetchRawBlock(ctx, bot.nextRound)
+				if archiveErr == nil {
+					err = bot.enqueueBlock(ctx, blockbytes)
+					if err != nil {
+						return fmt.Errorf("catchupLoop() err: %w", err)
+					}
+					bot.setError(nil)
+					bot.setUnhealthy(false)
+					bot.nextRound++
+					bot.failingSince = time.Time{}
+					bot.mainLoopFailures = 0
+					continue
+				}
+				if !errors.Is(archiveErr, ErrBlockArchiveNotFound) {
+					bot.log.WithError(archiveErr).Errorf("block archive lookup %d", bot.nextRound)
+				}
+			}
+
 			bot.log.WithError(err).Errorf("catchup block %d", bot.nextRound)
 			return nil
 		}
@@ -134,8 +215,10 @@ func (bot *fetcherImpl) catchupLoop(ctx context.Context) error {
 		}
 		// If we successfully handle the block, clear out any transient error which may have occurred.
 		bot.setError(nil)
+		bot.setUnhealthy(false)
 		bot.nextRound++
 		bot.failingSince = time.Time{}
+		bot.mainLoopFailures = 0
 	}
 }
 
@@ -145,7 +228,13 @@ func (bot *fetcherImpl) followLoop(ctx context.Context) error {
 	var blockbytes []byte
 	aclient := bot.Algod()
 	for {
-		for retries := 0; retries < 3; retries++ {
+		for retries := uint64(0); retries <= bot.retryPolicy.MaxRetries; retries++ {
+			if retries > 0 {
+				if sleepErr := sleep(ctx, bot.retryPolicy.backoff(int(retries)-1)); sleepErr != nil {
+					return fmt.Errorf("followLoop() err: %w", sleepErr)
+				}
+			}
+
 			// nextRound - 1 because the endpoint waits until "StatusAfterBlock"
 			_, err = aclient.StatusAfterBlock(bot.nextRound - 1).Do(ctx)
 			if err != nil {
@@ -181,8 +270,10 @@ func (bot *fetcherImpl) followLoop(ctx context.Context) error {
 		}
 		// Clear out any transient error which may have occurred.
 		bot.setError(nil)
+		bot.setUnhealthy(false)
 		bot.nextRound++
 		bot.failingSince = time.Time{}
+		bot.mainLoopFailures = 0
 	}
 }
 
@@ -203,8 +294,16 @@ func (bot *fetcherImpl) mainLoop(ctx context.Context) error {
 			now := time.Now()
 			dt := now.Sub(bot.failingSince)
 			bot.log.Warnf("failing to fetch from algod for %s, (since %s, now %s)", dt.String(), bot.failingSince.String(), now.String())
+			if bot.retryPolicy.StallTimeout > 0 && dt >= bot.retryPolicy.StallTimeout {
+				bot.setUnhealthy(true)
+			}
+		}
+
+		if sleepErr := sleep(ctx, bot.retryPolicy.backoff(bot.mainLoopFailures)); sleepErr != nil {
+			return fmt.Errorf("mainLoop() err: %w", sleepErr)
 		}
-		time.Sleep(5 * time.Second)
+		bot.mainLoopFailures++
+
 		err = bot.reclient()
 		if err != nil {
 			bot.setError(err)
@@ -248,6 +347,11 @@ func (bot *fetcherImpl) SetNextRound(nextRound uint64) {
 	bot.nextRound = nextRound
 }
 
+// SetBlockArchive is part of the Fetcher interface
+func (bot *fetcherImpl) SetBlockArchive(archive BlockArchive) {
+	bot.archive = archive
+}
+
 // AddBlockHandler is part of the Fetcher interface
 func (bot *fetcherImpl) SetBlockHandler(handler func(context.Context, *rpcs.EncodedBlockCert) error) {
 	bot.handler = handler
@@ -255,7 +359,7 @@ func (bot *fetcherImpl) SetBlockHandler(handler func(context.Context, *rpcs.Enco
 
 // ForDataDir initializes Fetcher to read data from the data directory.
 func ForDataDir(path string, log *log.Logger) (bot Fetcher, err error) {
-	boti := &fetcherImpl{algorandData: path, log: log}
+	boti := &fetcherImpl{algorandData: path, log: log, retryPolicy: DefaultRetryPolicy}
 	err = boti.reclient()
 	if err == nil {
 		bot = boti
@@ -273,7 +377,7 @@ func ForNetAndToken(netaddr, token string, log *log.Logger) (bot Fetcher, err er
 	if err != nil {
 		return
 	}
-	bot = &fetcherImpl{aclient: client, log: log}
+	bot = &fetcherImpl{aclient: client, log: log, retryPolicy: DefaultRetryPolicy}
 	return
 }
 
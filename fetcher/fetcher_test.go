@@ -98,6 +98,40 @@ func TestFetcherImplCatchupLoopBlockError(t *testing.T) {
 	require.Equal(t, "", fetcher.Error(), "FetcherImpl set an unexpected error from algod client during catchupLoop")
 }
 
+type fakeBlockArchive struct {
+	mock.Mock
+}
+
+func (a *fakeBlockArchive) FetchRawBlock(ctx context.Context, round uint64) ([]byte, error) {
+	args := a.Called(ctx, round)
+	blockbytes, _ := args.Get(0).([]byte)
+	return blockbytes, args.Error(1)
+}
+
+func TestFetcherImplCatchupLoopArchiveFallback(t *testing.T) {
+	aclient, err := test.MockAClient(test.NewAlgodHandler(
+		func(path string, w http.ResponseWriter) bool {
+			if strings.Contains(path, "v2/blocks/") {
+				w.WriteHeader(http.StatusNotFound)
+				return true
+			}
+			return false
+		}),
+	)
+	assert.NoError(t, err)
+
+	var block bookkeeping.Block
+	archive := &fakeBlockArchive{}
+	archive.On("FetchRawBlock", mock.Anything, uint64(0)).Return(protocol.Encode(&block), nil).Once()
+	archive.On("FetchRawBlock", mock.Anything, uint64(1)).Return([]byte(nil), ErrBlockArchiveNotFound).Once()
+
+	fetcher := &fetcherImpl{aclient: aclient, archive: archive, log: logrus.New(), blockQueue: make(chan *rpcs.EncodedBlockCert, 256)}
+	err = fetcher.catchupLoop(context.Background())
+	require.NoError(t, err, "FetcherImpl returned an unexpected error from catchupLoop")
+	require.Equal(t, uint64(1), fetcher.nextRound, "FetcherImpl did not advance past the round served by the archive")
+	archive.AssertExpectations(t)
+}
+
 func TestAlgodArgsForDataDirNetDoesNotExist(t *testing.T) {
 	_, _, _, err := AlgodArgsForDataDir("foobar")
 	assert.Error(t, err)
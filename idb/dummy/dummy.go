@@ -1,10 +1,24 @@
+// Package dummy implements a minimal, maintained idb.IndexerDb that keeps
+// just enough in-memory state (the next round to account, whether genesis
+// has been loaded) to let applications embedding the indexer libraries -
+// exporters, pipelines, etc. - unit test against a real pipeline without
+// standing up Postgres. It is intentionally not a full accounting engine:
+// most query methods return empty results, since most downstream tests only
+// care that blocks were accepted in order, and the ones documented as not
+// implemented (GetBlockHeaders, ApplicationBoxes, ParticipationUpdates,
+// BalanceHistory, AccountRewards, OnlineStakeHistory, AccountStateDelta)
+// panic.
 package dummy
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/algorand/indexer/helpers"
 	"github.com/algorand/indexer/idb"
 	"github.com/algorand/indexer/types"
 
@@ -16,34 +30,66 @@ import (
 
 type dummyIndexerDb struct {
 	log *log.Logger
+
+	mu            sync.Mutex
+	genesisLoaded bool
+	nextRound     uint64
 }
 
-// IndexerDb is a mock implementation of IndexerDb
+// IndexerDb returns an in-memory idb.IndexerDb suitable for unit testing.
 func IndexerDb() idb.IndexerDb {
-	return &dummyIndexerDb{}
+	l := log.New()
+	l.SetOutput(io.Discard)
+	return &dummyIndexerDb{log: l}
 }
 
 func (db *dummyIndexerDb) Close() {
 }
 
-func (db *dummyIndexerDb) AddBlock(block *ledgercore.ValidatedBlock) error {
-	db.log.Printf("AddBlock")
+func (db *dummyIndexerDb) AddBlock(vblk *ledgercore.ValidatedBlock) error {
+	vb, err := helpers.ConvertValidatedBlock(*vblk)
+	if err != nil {
+		return fmt.Errorf("AddBlock() err: %w", err)
+	}
+	round := vb.Block.BlockHeader.Round
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if round != sdk.Round(db.nextRound) {
+		return fmt.Errorf(
+			"AddBlock() adding block round %d but next round to account is %d",
+			round, db.nextRound)
+	}
+	db.nextRound++
+
+	db.log.Printf("AddBlock round %d", round)
 	return nil
 }
 
 // LoadGenesis is part of idb.IndexerDB
 func (db *dummyIndexerDb) LoadGenesis(genesis bookkeeping.Genesis) (err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.genesisLoaded = true
 	return nil
 }
 
 // GetNextRoundToAccount is part of idb.IndexerDB
 func (db *dummyIndexerDb) GetNextRoundToAccount() (uint64, error) {
-	return 0, nil
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !db.genesisLoaded {
+		return 0, idb.ErrorNotInitialized
+	}
+	return db.nextRound, nil
 }
 
 // GetNextRoundToLoad is part of idb.IndexerDB
 func (db *dummyIndexerDb) GetNextRoundToLoad() (uint64, error) {
-	return 0, nil
+	return db.GetNextRoundToAccount()
 }
 
 // GetSpecialAccounts is part of idb.IndexerDb
@@ -56,16 +102,31 @@ func (db *dummyIndexerDb) GetBlock(ctx context.Context, round uint64, options id
 	return sdk.BlockHeader{}, nil, nil
 }
 
+// GetBlockHeaders isn't currently implemented
+func (db *dummyIndexerDb) GetBlockHeaders(ctx context.Context, filter idb.BlockHeadersQuery) (<-chan idb.BlockHeaderRow, uint64) {
+	panic("not implemented")
+}
+
 // Transactions is part of idb.IndexerDB
 func (db *dummyIndexerDb) Transactions(ctx context.Context, tf idb.TransactionFilter) (<-chan idb.TxnRow, uint64) {
 	return nil, 0
 }
 
+// EstimateTransactionsCount is part of idb.IndexerDB
+func (db *dummyIndexerDb) EstimateTransactionsCount(ctx context.Context, tf idb.TransactionFilter) (uint64, error) {
+	return 0, nil
+}
+
 // GetAccounts is part of idb.IndexerDB
 func (db *dummyIndexerDb) GetAccounts(ctx context.Context, opts idb.AccountQueryOptions) (<-chan idb.AccountRow, uint64) {
 	return nil, 0
 }
 
+// EstimateAccountsCount is part of idb.IndexerDB
+func (db *dummyIndexerDb) EstimateAccountsCount(ctx context.Context, opts idb.AccountQueryOptions) (uint64, error) {
+	return 0, nil
+}
+
 // Assets is part of idb.IndexerDB
 func (db *dummyIndexerDb) Assets(ctx context.Context, filter idb.AssetsQuery) (<-chan idb.AssetRow, uint64) {
 	return nil, 0
@@ -91,6 +152,31 @@ func (db *dummyIndexerDb) ApplicationBoxes(ctx context.Context, filter idb.Appli
 	panic("not implemented")
 }
 
+// ParticipationUpdates isn't currently implemented
+func (db *dummyIndexerDb) ParticipationUpdates(ctx context.Context, filter idb.ParticipationUpdateQuery) (<-chan idb.ParticipationUpdateRow, uint64) {
+	panic("not implemented")
+}
+
+// BalanceHistory isn't currently implemented
+func (db *dummyIndexerDb) BalanceHistory(ctx context.Context, filter idb.BalanceHistoryQuery) (<-chan idb.BalanceHistoryRow, uint64) {
+	panic("not implemented")
+}
+
+// AccountRewards isn't currently implemented
+func (db *dummyIndexerDb) AccountRewards(ctx context.Context, filter idb.RewardsQuery) (idb.RewardsSummary, uint64, error) {
+	panic("not implemented")
+}
+
+// OnlineStakeHistory isn't currently implemented
+func (db *dummyIndexerDb) OnlineStakeHistory(ctx context.Context, filter idb.OnlineStakeHistoryQuery) (<-chan idb.OnlineStakeHistoryRow, uint64) {
+	panic("not implemented")
+}
+
+// AccountStateDelta isn't currently implemented
+func (db *dummyIndexerDb) AccountStateDelta(ctx context.Context, query idb.AccountStateDeltaQuery) (<-chan idb.AccountStateDeltaRow, uint64) {
+	panic("not implemented")
+}
+
 // Health is part of idb.IndexerDB
 func (db *dummyIndexerDb) Health(ctx context.Context) (state idb.Health, err error) {
 	return idb.Health{}, nil
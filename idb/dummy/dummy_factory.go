@@ -15,10 +15,15 @@ func (df dummyFactory) Name() string {
 }
 
 // Build is part of the IndexerFactory interface.
-func (df dummyFactory) Build(arg string, opts idb.IndexerDbOptions, log *log.Logger) (idb.IndexerDb, chan struct{}, error) {
+func (df dummyFactory) Build(arg string, opts idb.IndexerDbOptions, logger *log.Logger) (idb.IndexerDb, chan struct{}, error) {
+	db := IndexerDb().(*dummyIndexerDb)
+	if logger != nil {
+		db.log = logger
+	}
+
 	ch := make(chan struct{})
 	close(ch)
-	return &dummyIndexerDb{log: log}, ch, nil
+	return db, ch, nil
 }
 
 func init() {
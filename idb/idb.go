@@ -154,6 +154,20 @@ var ErrorNotInitialized error = errors.New("accounting not initialized")
 // ErrorBlockNotFound is used when requesting a block that isn't in the DB.
 var ErrorBlockNotFound = errors.New("block not found")
 
+// ErrorSchemaNewerThanBinary is returned when the DB's schema/migration
+// number is newer than any migration this binary knows about, i.e. the DB
+// was last migrated by a newer indexer release. Running against it would be
+// undefined behavior, so backends should refuse to start instead.
+// IndexerDbOptions.AllowUnsupportedSchema overrides this in read-only mode.
+var ErrorSchemaNewerThanBinary = errors.New("database schema is newer than this indexer binary supports")
+
+// ErrorBlockReorg is returned by AddBlock when a round that was already
+// imported is added again with a different block header. AddBlock is
+// otherwise idempotent: re-adding a round with an identical header is a
+// silent no-op, so retry logic and at-least-once import pipelines don't
+// need to track what they've already submitted.
+var ErrorBlockReorg = errors.New("attempted to import a different block for an already-imported round")
+
 // IndexerDb is the interface used to define alternative Indexer backends.
 // TODO: sqlite3 impl
 // TODO: cockroachdb impl
@@ -177,13 +191,26 @@ type IndexerDb interface {
 
 	// The next multiple functions return a channel with results as well as the latest round
 	// accounted.
+	GetBlockHeaders(ctx context.Context, filter BlockHeadersQuery) (<-chan BlockHeaderRow, uint64)
 	Transactions(ctx context.Context, tf TransactionFilter) (<-chan TxnRow, uint64)
+	// EstimateTransactionsCount returns the query planner's row-count
+	// estimate for tf, without running the query. It is for callers that
+	// want an approximate result count cheaper than a real COUNT(*).
+	EstimateTransactionsCount(ctx context.Context, tf TransactionFilter) (uint64, error)
 	GetAccounts(ctx context.Context, opts AccountQueryOptions) (<-chan AccountRow, uint64)
+	// EstimateAccountsCount is the accounts analogue of
+	// EstimateTransactionsCount.
+	EstimateAccountsCount(ctx context.Context, opts AccountQueryOptions) (uint64, error)
 	Assets(ctx context.Context, filter AssetsQuery) (<-chan AssetRow, uint64)
 	AssetBalances(ctx context.Context, abq AssetBalanceQuery) (<-chan AssetBalanceRow, uint64)
 	Applications(ctx context.Context, filter ApplicationQuery) (<-chan ApplicationRow, uint64)
 	AppLocalState(ctx context.Context, filter ApplicationQuery) (<-chan AppLocalStateRow, uint64)
 	ApplicationBoxes(ctx context.Context, filter ApplicationBoxQuery) (<-chan ApplicationBoxRow, uint64)
+	ParticipationUpdates(ctx context.Context, filter ParticipationUpdateQuery) (<-chan ParticipationUpdateRow, uint64)
+	BalanceHistory(ctx context.Context, filter BalanceHistoryQuery) (<-chan BalanceHistoryRow, uint64)
+	AccountRewards(ctx context.Context, filter RewardsQuery) (RewardsSummary, uint64, error)
+	OnlineStakeHistory(ctx context.Context, filter OnlineStakeHistoryQuery) (<-chan OnlineStakeHistoryRow, uint64)
+	AccountStateDelta(ctx context.Context, query AccountStateDeltaQuery) (<-chan AccountStateDeltaRow, uint64)
 
 	Health(ctx context.Context) (status Health, err error)
 
@@ -199,6 +226,31 @@ type GetBlockOptions struct {
 	MaxTransactionsLimit uint64
 }
 
+// BlockHeadersQuery is a parameter object used to fetch a range of block
+// headers (no transactions) in one call, so explorers can render
+// recent-blocks lists without a GetBlock request per block.
+type BlockHeadersQuery struct {
+	MinRound uint64
+	MaxRound uint64
+
+	// Limit is the maximum number of headers to return.
+	Limit uint64
+}
+
+// BlockHeaderRow is one block header returned by a GetBlockHeaders query.
+type BlockHeaderRow struct {
+	Round  uint64
+	Header sdk.BlockHeader
+	Error  error
+}
+
+// NotePrefixLength is how many leading bytes of a transaction's note field
+// are stored in txn.note_prefix, indexed so filtering by NotePrefix can use
+// that index instead of decoding and scanning every row's note. A
+// NotePrefix filter longer than this still works, but falls back to
+// checking the remaining bytes against the full decoded note.
+const NotePrefixLength = 8
+
 // TransactionFilter is a parameter object with all the transaction filter options.
 type TransactionFilter struct {
 	// Address filtering transactions for one Address will
@@ -215,15 +267,37 @@ type TransactionFilter struct {
 	BeforeTime time.Time
 	TypeEnum   TxnTypeEnum // ["","pay","keyreg","acfg","axfer","afrz"]
 	Txid       string
-	Round      *uint64 // nil for no filter
-	Offset     *uint64 // nil for no filter
-	OffsetLT   *uint64 // nil for no filter
-	OffsetGT   *uint64 // nil for no filter
-	SigType    SigType // ["", "sig", "msig", "lsig"]
-	NotePrefix []byte
-	AlgosGT    *uint64 // implictly filters on "pay" txns for Algos > this. This will be a slightly faster query than EffectiveAmountGT.
-	AlgosLT    *uint64
-	RekeyTo    *bool // nil for no filter
+	// GroupID filters for the root transactions (and their inner
+	// transactions) belonging to an atomic transaction group. Only root
+	// transactions carry a group id of their own, so this never directly
+	// matches an inner transaction.
+	GroupID  []byte
+	Round    *uint64 // nil for no filter
+	Offset   *uint64 // nil for no filter
+	OffsetLT *uint64 // nil for no filter
+	OffsetGT *uint64 // nil for no filter
+	SigType  SigType // ["", "sig", "msig", "lsig"]
+	// LogicSigHash filters for transactions signed by the LogicSig program
+	// whose hash (its escrow account address) matches this value.
+	LogicSigHash []byte
+	// MultisigSubsigner filters for transactions signed by a multisig (or
+	// delegated LogicSig multisig) that includes this address among its
+	// subsigners, regardless of whether that subsigner actually signed.
+	MultisigSubsigner []byte
+	NotePrefix        []byte
+	AlgosGT           *uint64 // implictly filters on "pay" txns for Algos > this. This will be a slightly faster query than EffectiveAmountGT.
+	AlgosLT           *uint64
+	RekeyTo           *bool // nil for no filter
+	// ClosedAccount filters for "pay" txns whose CloseRemainderTo actually
+	// closed the sender's Algo balance (the "ca" ApplyData field is nonzero).
+	ClosedAccount *bool // nil for no filter
+	// ClosedAssetHolding filters for "axfer" txns whose AssetCloseTo actually
+	// closed the sender's asset holding (the "aca" ApplyData field is nonzero).
+	ClosedAssetHolding *bool // nil for no filter
+	// AssetSenderSet filters for "axfer" txns that carry an explicit
+	// AssetSender (the "asnd" field), which is only set when the transaction
+	// is a clawback rather than an ordinary transfer.
+	AssetSenderSet *bool // nil for no filter
 
 	AssetID       uint64 // filter transactions relevant to an asset
 	AssetAmountGT *uint64
@@ -234,6 +308,14 @@ type TransactionFilter struct {
 	EffectiveAmountGT *uint64 // Algo: Amount + CloseAmount > x
 	EffectiveAmountLT *uint64 // Algo: Amount + CloseAmount < x
 
+	// MinFee and MaxFee filter against the indexed txn.fee column, so
+	// fee-market analysis tools can query directly for transactions in a
+	// fee range instead of post-filtering a large result set client-side.
+	// Only transactions imported after the fee column's migration have it
+	// populated, so these filters never match older transactions.
+	MinFee *uint64 // nil for no filter
+	MaxFee *uint64 // nil for no filter
+
 	// pointer to last returned object of previous query
 	NextToken string
 
@@ -248,11 +330,38 @@ type TransactionFilter struct {
 	HeaderOnly bool
 }
 
+// AccountOrderBy selects which column GetAccounts results are sorted by.
+// The zero value, AccountOrderByAddress, is the original behavior: sort by
+// address ascending, paged with GreaterThanAddress alone.
+type AccountOrderBy string
+
+const (
+	// AccountOrderByAddress sorts by address ascending.
+	AccountOrderByAddress AccountOrderBy = ""
+	// AccountOrderByBalance sorts by current Algo balance, for leaderboards.
+	AccountOrderByBalance AccountOrderBy = "balance"
+	// AccountOrderByCreatedAt sorts by the round the account was first created.
+	AccountOrderByCreatedAt AccountOrderBy = "created-at"
+	// AccountOrderByLastActive sorts by the round of the account's most
+	// recent transaction, for "recently active" dashboards.
+	AccountOrderByLastActive AccountOrderBy = "last-active"
+)
+
 // AccountQueryOptions is a parameter object with all of the account filter options.
 type AccountQueryOptions struct {
 	GreaterThanAddress []byte // for paging results
 	EqualToAddress     []byte // return exactly this one account
 
+	// OrderBy selects the sort column; see AccountOrderBy.
+	OrderBy AccountOrderBy
+	// OrderDescending reverses OrderBy's sort direction; ascending otherwise.
+	OrderDescending bool
+	// GreaterThanOrderValue is the paging cursor's OrderBy column value when
+	// OrderBy is not AccountOrderByAddress. GreaterThanAddress is still
+	// required alongside it, to break ties between accounts that share a
+	// value for the OrderBy column.
+	GreaterThanOrderValue *uint64
+
 	// return any accounts with this auth addr
 	EqualToAuthAddr []byte
 
@@ -261,9 +370,10 @@ type AccountQueryOptions struct {
 	// Filter on accounts with current balance less than x.
 	AlgosLessThan *uint64
 
-	// HasAssetID, AssetGT, and AssetLT are implemented in Go code
-	// after data has returned from Postgres and thus are slightly
-	// less efficient. They will turn on IncludeAssetHoldings.
+	// HasAssetID restricts results to accounts holding this asset;
+	// AssetGT and AssetLT further bound that holding's amount. All
+	// three are pushed down into the account_asset table in SQL, not
+	// filtered after the fact. They will turn on IncludeAssetHoldings.
 	HasAssetID uint64
 	AssetGT    *uint64
 	AssetLT    *uint64
@@ -281,6 +391,15 @@ type AccountQueryOptions struct {
 	// IncludeDeleted indicated whether to include deleted Assets, Applications, etc within the account.
 	IncludeDeleted bool
 
+	// OnlineOnly restricts results to accounts with Status Online.
+	OnlineOnly bool
+	// MaxLastHeartbeatRound, when OnlineOnly is set, further restricts results
+	// to online accounts whose last heartbeat (most recent key registration
+	// transaction) round is at or before this round, or who have never sent
+	// one. Callers compute this as currentRound-N to serve a "stale for N
+	// rounds" query for consensus-health monitoring.
+	MaxLastHeartbeatRound *uint64
+
 	Limit uint64
 }
 
@@ -319,6 +438,22 @@ type AssetsQuery struct {
 	// IncludeDeleted indicated whether to include deleted Assets in the results.
 	IncludeDeleted bool
 
+	// MinHolders, if nonzero, restricts results to assets with at least this
+	// many current opt-ins.
+	MinHolders uint64
+
+	// CreatedAfterRound and CreatedBeforeRound, if nonzero, restrict results
+	// to assets created strictly after/before the given round.
+	CreatedAfterRound  uint64
+	CreatedBeforeRound uint64
+
+	// DestroyedAfterRound and DestroyedBeforeRound, if nonzero, restrict
+	// results to assets destroyed strictly after/before the given round.
+	// Setting either implies IncludeDeleted, since a destroyed asset is
+	// otherwise excluded from results.
+	DestroyedAfterRound  uint64
+	DestroyedBeforeRound uint64
+
 	Limit uint64
 }
 
@@ -331,6 +466,11 @@ type AssetRow struct {
 	CreatedRound *uint64
 	ClosedRound  *uint64
 	Deleted      *bool
+	NumHolders   uint64
+	// ClosingTxid is the base32 txid of the transaction that destroyed this
+	// asset, or nil if it's still live or was destroyed by an inner
+	// transaction.
+	ClosingTxid []byte
 }
 
 // AssetBalanceQuery is a parameter object with all of the asset balance filter options.
@@ -377,6 +517,12 @@ type ApplicationQuery struct {
 	ApplicationIDGreaterThan uint64
 	IncludeDeleted           bool
 	Limit                    uint64
+	// Round, when set, returns global/local state as of that round instead of
+	// the latest state, reconstructed from app_state_history. Only honored
+	// when IndexerDbOptions.EnableAppStateHistory was set when the history
+	// being queried was recorded; otherwise the state for rounds before
+	// history tracking was enabled cannot be reconstructed.
+	Round *uint64
 }
 
 // AppLocalStateRow is metadata and local state (AppLocalState) relating to one application in an application query.
@@ -402,9 +548,102 @@ type ApplicationBoxRow struct {
 	Error error
 }
 
+// ParticipationUpdateQuery is a parameter object used to query the rounds at
+// which an account's participation keys were marked expired.
+type ParticipationUpdateQuery struct {
+	Address []byte
+	Limit   uint64
+}
+
+// ParticipationUpdateRow is one round at which an account was marked expired.
+type ParticipationUpdateRow struct {
+	Round uint64
+	Error error
+}
+
+// BalanceHistoryQuery is a parameter object used to query an account's
+// historical balance time series.
+type BalanceHistoryQuery struct {
+	Address     []byte
+	AfterRound  uint64
+	BeforeRound uint64
+	Limit       uint64
+}
+
+// BalanceHistoryRow is one round at which an account's balance was recorded.
+type BalanceHistoryRow struct {
+	Round      uint64
+	Microalgos uint64
+	Error      error
+}
+
+// AccountStateDeltaQuery is a parameter object used to query which accounts
+// had their data updated between two rounds, with their balances before and
+// after.
+type AccountStateDeltaQuery struct {
+	MinRound uint64
+	MaxRound uint64
+	Limit    uint64
+
+	// GreaterThanAddress pages results: when set, only accounts sorting
+	// after this address (the last address returned by the previous page)
+	// are considered.
+	GreaterThanAddress []byte
+}
+
+// AccountStateDeltaRow describes one account whose data was updated after
+// MinRound and at or before MaxRound: it held BeforeMicroalgos as of MinRound
+// (0 if the account did not yet exist) and AfterMicroalgos as of MaxRound.
+// The two can be equal when the account's balance itself did not change.
+type AccountStateDeltaRow struct {
+	Address          []byte
+	BeforeMicroalgos uint64
+	AfterMicroalgos  uint64
+	Error            error
+}
+
+// OnlineStakeHistoryQuery is a parameter object used to query the network's
+// historical total online stake time series.
+type OnlineStakeHistoryQuery struct {
+	AfterRound  uint64
+	BeforeRound uint64
+	Limit       uint64
+}
+
+// OnlineStakeHistoryRow is the total online stake recorded for one round.
+type OnlineStakeHistoryRow struct {
+	Round       uint64
+	OnlineStake uint64
+	Error       error
+}
+
+// RewardsQuery is a parameter object used to summarize rewards earned by an
+// account over a round range.
+type RewardsQuery struct {
+	Address     []byte
+	AfterRound  uint64
+	BeforeRound uint64
+}
+
+// RewardsSummary summarizes the rewards an account earned between MinRound
+// and MaxRound, the earliest and latest rounds at which its rewards total
+// was recorded within the requested range.
+type RewardsSummary struct {
+	MinRound      uint64
+	MaxRound      uint64
+	RewardsEarned uint64
+}
+
 // IndexerDbOptions are the options common to all indexer backends.
 type IndexerDbOptions struct {
 	ReadOnly bool
+
+	// AllowUnsupportedSchema overrides ErrorSchemaNewerThanBinary, letting a
+	// binary serve reads against a DB schema newer than it supports. Only
+	// honored when ReadOnly is set; a writer always refuses to run against an
+	// unsupported schema.
+	AllowUnsupportedSchema bool
+
 	// Maximum connection number for connection pool
 	// This means the total number of active queries that can be running
 	// concurrently can never be more than this
@@ -414,6 +653,60 @@ type IndexerDbOptions struct {
 	AlgodDataDir   string
 	AlgodToken     string
 	AlgodAddr      string
+
+	// VacuumInterval configures the built-in maintenance scheduler's periodic
+	// VACUUM/bloat-report pass. Zero disables it. Only honored by backends
+	// that support it (currently postgres).
+	VacuumInterval time.Duration
+
+	// DryRun runs the importer's full fetch/decode/accounting pipeline
+	// against the real database without persisting any of it: every write
+	// is issued inside a transaction that gets rolled back. Useful for soak
+	// testing new accounting code against live traffic. Only honored by
+	// backends that support it (currently postgres).
+	DryRun bool
+
+	// EnableAppStateHistory, when true, makes the accounting stage also
+	// record every application global/local state key's value into a history
+	// table, so later queries can ask for state as of a past round instead of
+	// only the latest state. Off by default since it adds a write per
+	// changed key per round. Only honored by backends that support it
+	// (currently postgres).
+	EnableAppStateHistory bool
+
+	// EnableBalanceHistory, when true, makes the accounting stage also record
+	// a checkpoint of an account's balance into a history table for every
+	// round in which the account's data is updated (including every round
+	// its balance changes), so GET /v2/accounts/{addr}/balance-history can return a time
+	// series without clients reconstructing it from raw transactions. Off by
+	// default since it adds a write per changed balance per round. Only
+	// honored by backends that support it (currently postgres).
+	EnableBalanceHistory bool
+
+	// EnableRewardsHistory, when true, makes the accounting stage also
+	// record an account's cumulative rewards total into a history table for
+	// every round in which the account's data is updated, so AccountRewards can summarize rewards earned
+	// over a round range without clients reconstructing it by diffing
+	// transactions. Off by default since it adds a write per changed
+	// rewards total per round. Only honored by backends that support it
+	// (currently postgres).
+	EnableRewardsHistory bool
+
+	// EnableOnlineStakeHistory, when true, makes the accounting stage also
+	// record the network's total online stake into a history table every
+	// round, so a time series of it can be served without clients
+	// reconstructing it from account status changes. Off by default since it
+	// adds a write per round. Only honored by backends that support it
+	// (currently postgres).
+	EnableOnlineStakeHistory bool
+
+	// MaxBatchStatements bounds how many statements the accounting stage
+	// queues into a single batch before flushing it to the database, so a
+	// round with thousands of account/asset/app changes doesn't build one
+	// unbounded batch in memory and on the wire. Zero uses the backend's
+	// own default. Only honored by backends that support it (currently
+	// postgres).
+	MaxBatchStatements uint32
 }
 
 // Health is the response object that IndexerDb objects need to return from the Health method.
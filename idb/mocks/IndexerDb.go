@@ -25,6 +25,57 @@ type IndexerDb struct {
 	mock.Mock
 }
 
+// AccountRewards provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) AccountRewards(ctx context.Context, filter idb.RewardsQuery) (idb.RewardsSummary, uint64, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 idb.RewardsSummary
+	if rf, ok := ret.Get(0).(func(context.Context, idb.RewardsQuery) idb.RewardsSummary); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(idb.RewardsSummary)
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.RewardsQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, idb.RewardsQuery) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AccountStateDelta provides a mock function with given fields: ctx, query
+func (_m *IndexerDb) AccountStateDelta(ctx context.Context, query idb.AccountStateDeltaQuery) (<-chan idb.AccountStateDeltaRow, uint64) {
+	ret := _m.Called(ctx, query)
+
+	var r0 <-chan idb.AccountStateDeltaRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.AccountStateDeltaQuery) <-chan idb.AccountStateDeltaRow); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.AccountStateDeltaRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.AccountStateDeltaQuery) uint64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
 // AddBlock provides a mock function with given fields: block
 func (_m *IndexerDb) AddBlock(block *ledgercore.ValidatedBlock) error {
 	ret := _m.Called(block)
@@ -154,6 +205,29 @@ func (_m *IndexerDb) Assets(ctx context.Context, filter idb.AssetsQuery) (<-chan
 	return r0, r1
 }
 
+// BalanceHistory provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) BalanceHistory(ctx context.Context, filter idb.BalanceHistoryQuery) (<-chan idb.BalanceHistoryRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.BalanceHistoryRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.BalanceHistoryQuery) <-chan idb.BalanceHistoryRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.BalanceHistoryRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.BalanceHistoryQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *IndexerDb) Close() {
 	_m.Called()
@@ -173,6 +247,48 @@ func (_m *IndexerDb) DeleteTransactions(ctx context.Context, keep uint64) error
 	return r0
 }
 
+// EstimateAccountsCount provides a mock function with given fields: ctx, opts
+func (_m *IndexerDb) EstimateAccountsCount(ctx context.Context, opts idb.AccountQueryOptions) (uint64, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, idb.AccountQueryOptions) uint64); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idb.AccountQueryOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateTransactionsCount provides a mock function with given fields: ctx, tf
+func (_m *IndexerDb) EstimateTransactionsCount(ctx context.Context, tf idb.TransactionFilter) (uint64, error) {
+	ret := _m.Called(ctx, tf)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, idb.TransactionFilter) uint64); ok {
+		r0 = rf(ctx, tf)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idb.TransactionFilter) error); ok {
+		r1 = rf(ctx, tf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccounts provides a mock function with given fields: ctx, opts
 func (_m *IndexerDb) GetAccounts(ctx context.Context, opts idb.AccountQueryOptions) (<-chan idb.AccountRow, uint64) {
 	ret := _m.Called(ctx, opts)
@@ -226,6 +342,29 @@ func (_m *IndexerDb) GetBlock(ctx context.Context, round uint64, options idb.Get
 	return r0, r1, r2
 }
 
+// GetBlockHeaders provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) GetBlockHeaders(ctx context.Context, filter idb.BlockHeadersQuery) (<-chan idb.BlockHeaderRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.BlockHeaderRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.BlockHeadersQuery) <-chan idb.BlockHeaderRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.BlockHeaderRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.BlockHeadersQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
 // GetNetworkState provides a mock function with given fields:
 func (_m *IndexerDb) GetNetworkState() (idb.NetworkState, error) {
 	ret := _m.Called()
@@ -324,6 +463,52 @@ func (_m *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 	return r0
 }
 
+// OnlineStakeHistory provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) OnlineStakeHistory(ctx context.Context, filter idb.OnlineStakeHistoryQuery) (<-chan idb.OnlineStakeHistoryRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.OnlineStakeHistoryRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.OnlineStakeHistoryQuery) <-chan idb.OnlineStakeHistoryRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.OnlineStakeHistoryRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.OnlineStakeHistoryQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// ParticipationUpdates provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) ParticipationUpdates(ctx context.Context, filter idb.ParticipationUpdateQuery) (<-chan idb.ParticipationUpdateRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.ParticipationUpdateRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.ParticipationUpdateQuery) <-chan idb.ParticipationUpdateRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.ParticipationUpdateRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.ParticipationUpdateQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
 // SetNetworkState provides a mock function with given fields: genesis
 func (_m *IndexerDb) SetNetworkState(genesis bookkeeping.Genesis) error {
 	ret := _m.Called(genesis)
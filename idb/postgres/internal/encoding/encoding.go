@@ -4,8 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 
-	"github.com/algorand/go-codec/codec"
-
+	"github.com/algorand/indexer/encoding"
 	"github.com/algorand/indexer/idb"
 	"github.com/algorand/indexer/idb/postgres/internal/types"
 	itypes "github.com/algorand/indexer/types"
@@ -17,31 +16,14 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 )
 
-var jsonCodecHandle *codec.JsonHandle
-
-func init() {
-	jsonCodecHandle = new(codec.JsonHandle)
-	jsonCodecHandle.ErrorIfNoField = true
-	jsonCodecHandle.ErrorIfNoArrayExpand = true
-	jsonCodecHandle.Canonical = true
-	jsonCodecHandle.RecursiveEmptyCheck = true
-	jsonCodecHandle.HTMLCharsAsIs = true
-	jsonCodecHandle.Indent = 0
-	jsonCodecHandle.MapKeyAsString = true
-}
-
 // encodeJSON converts an object into JSON
 func encodeJSON(obj interface{}) []byte {
-	var buf []byte
-	enc := codec.NewEncoderBytes(&buf, jsonCodecHandle)
-	enc.MustEncode(obj)
-	return buf
+	return encoding.Marshal(obj)
 }
 
 // DecodeJSON is a function that decodes json.
 func DecodeJSON(b []byte, objptr interface{}) error {
-	dec := codec.NewDecoderBytes(b, jsonCodecHandle)
-	return dec.Decode(objptr)
+	return encoding.Unmarshal(b, objptr)
 }
 
 // Base64 encodes a byte array to a base64 string.
@@ -114,6 +96,17 @@ func convertAssetParams(params sdk.AssetParams) assetParams {
 		ret.URLBytes = nil
 	}
 
+	// AssetName/UnitName are only populated when the name is entirely
+	// printable UTF8; otherwise store a lossy sanitized projection so search
+	// still has something to match against. Skip it when it would just
+	// duplicate AssetName/UnitName.
+	if ret.AssetName == "" {
+		ret.AssetNameSearch = util.SanitizePrintableUTF8(params.AssetName)
+	}
+	if ret.UnitName == "" {
+		ret.UnitNameSearch = util.SanitizePrintableUTF8(params.UnitName)
+	}
+
 	return ret
 }
 
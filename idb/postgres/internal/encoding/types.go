@@ -25,6 +25,14 @@ type assetParams struct {
 	ReserveOverride  sdk.Digest `codec:"r"`
 	FreezeOverride   sdk.Digest `codec:"f"`
 	ClawbackOverride sdk.Digest `codec:"c"`
+	// UnitNameSearch and AssetNameSearch hold a lossy, always-valid-UTF8
+	// projection of the unit/asset name, for substring search to match
+	// against even when the name isn't printable UTF8 (so UnitName/AssetName
+	// is empty and the real bytes only live in UnitNameBytes/AssetNameBytes).
+	// They're omitted from the encoding when they'd equal UnitName/AssetName,
+	// since in that case searching the latter already works.
+	UnitNameSearch  string `codec:"uns,omitempty"`
+	AssetNameSearch string `codec:"ans,omitempty"`
 }
 
 type transaction struct {
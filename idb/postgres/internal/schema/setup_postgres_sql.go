@@ -25,11 +25,26 @@ CREATE TABLE IF NOT EXISTS txn (
   txid bytea, -- base32 of [32]byte hash, or NULL for inner transactions.
   txn jsonb NOT NULL, -- json encoding of signed txn with apply data; inner txns exclude nested inner txns
   extra jsonb NOT NULL,
+  note_prefix bytea, -- first 8 bytes of the decoded note field, or NULL if there is no note; indexed for note-prefix search
+  lsig_hash bytea, -- LogicSigAddress of the txn's LogicSig program, or NULL if it isn't LogicSig-signed
+  fee bigint, -- transaction fee in microalgos; indexed for min-fee/max-fee search
   PRIMARY KEY ( round, intra )
 );
 
--- For transaction lookup
-CREATE INDEX IF NOT EXISTS txn_by_tixid ON txn ( txid );
+-- For transaction lookup; partial because inner transactions always have a
+-- NULL txid and outnumber root transactions, so indexing them would bloat
+-- the index without ever being useful.
+CREATE INDEX IF NOT EXISTS txn_by_tixid ON txn ( txid ) WHERE txid IS NOT NULL;
+
+-- For note-prefix search; only useful for prefixes up to 8 bytes, longer
+-- prefixes still need a secondary scan over the matched rows.
+CREATE INDEX IF NOT EXISTS txn_by_note_prefix ON txn ( note_prefix ) WHERE note_prefix IS NOT NULL;
+
+-- For min-fee/max-fee search.
+CREATE INDEX IF NOT EXISTS txn_by_fee ON txn ( fee ) WHERE fee IS NOT NULL;
+
+-- For searching by LogicSig program hash
+CREATE INDEX IF NOT EXISTS txn_by_lsig_hash ON txn ( lsig_hash ) WHERE lsig_hash IS NOT NULL;
 
 -- Optional, to make txn queries by asset fast:
 -- CREATE INDEX CONCURRENTLY IF NOT EXISTS txn_asset ON txn (asset, round, intra);
@@ -43,6 +58,18 @@ CREATE TABLE IF NOT EXISTS txn_participation (
 -- For query account transactions
 CREATE UNIQUE INDEX IF NOT EXISTS txn_participation_i ON txn_participation ( addr, round DESC, intra DESC );
 
+-- addresses that are subsigners of the multisig (or delegated LogicSig
+-- multisig) that authorized a transaction, for compliance tracing of
+-- delegated signatures. Not deduplicated against txn_participation because
+-- a subsigner need not otherwise be a party to the transaction.
+CREATE TABLE IF NOT EXISTS txn_multisig_participation (
+  addr bytea NOT NULL,
+  round bigint NOT NULL,
+  intra integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS txn_multisig_participation_i ON txn_multisig_participation ( addr, round DESC, intra DESC );
+
 -- expand data.basics.AccountData
 CREATE TABLE IF NOT EXISTS account (
   addr bytea primary key,
@@ -53,9 +80,22 @@ CREATE TABLE IF NOT EXISTS account (
   created_at bigint NOT NULL, -- round that the account is first used
   closed_at bigint, -- round that the account was last closed
   keytype varchar(8), -- "sig", "msig", "lsig", or NULL if unknown
-  account_data jsonb NOT NULL -- trimmed ledgercore.AccountData that excludes the fields above; SQL 'NOT NULL' is held though the json string will be "null" iff account is deleted
+  account_data jsonb NOT NULL, -- trimmed ledgercore.AccountData that excludes the fields above; SQL 'NOT NULL' is held though the json string will be "null" iff account is deleted
+  total_transactions bigint NOT NULL DEFAULT 0, -- count of transactions (including inner) that reference this account, maintained incrementally on import
+  last_active_round bigint NOT NULL DEFAULT 0, -- round of the most recent transaction referencing this account
+  last_heartbeat_round bigint NOT NULL DEFAULT 0 -- round of the most recent key registration transaction sent by this account, used as a proxy for online participation liveness since block proposer data isn't imported
 );
 
+-- For ordering /v2/accounts results by balance, created_at, or last_active_round
+-- instead of only address, for leaderboard and "recently active" dashboards.
+CREATE INDEX IF NOT EXISTS account_by_microalgos ON account ( microalgos, addr ) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS account_by_created_at ON account ( created_at, addr ) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS account_by_last_active_round ON account ( last_active_round, addr ) WHERE NOT deleted;
+
+-- For the "online accounts stale for N rounds" query, which filters on
+-- status in account_data's JSONB alongside last_heartbeat_round.
+CREATE INDEX IF NOT EXISTS account_by_last_heartbeat_round ON account ( last_heartbeat_round, addr ) WHERE NOT deleted;
+
 -- data.basics.AccountData Assets[asset id] AssetHolding{}
 CREATE TABLE IF NOT EXISTS account_asset (
   addr bytea NOT NULL, -- [32]byte
@@ -78,10 +118,12 @@ CREATE INDEX IF NOT EXISTS account_asset_by_addr_partial ON account_asset(addr)
 CREATE TABLE IF NOT EXISTS asset (
   index bigint PRIMARY KEY,
   creator_addr bytea NOT NULL,
-  params jsonb NOT NULL, -- data.basics.AssetParams; json string "null" iff asset is deleted
+  params jsonb NOT NULL, -- data.basics.AssetParams; retains the asset's last-known parameters after it's deleted, for auditors that need its final state
   deleted bool NOT NULL, -- whether or not it is currently deleted
   created_at bigint NOT NULL, -- round that the asset was created
-  closed_at bigint -- round that the asset was closed; cannot be recreated because the index is unique
+  closed_at bigint, -- round that the asset was closed; cannot be recreated because the index is unique
+  closing_txid bytea, -- txid of the transaction that destroyed the asset, or NULL if it's still live or was destroyed by an inner transaction
+  num_holders bigint NOT NULL DEFAULT 0 -- count of accounts currently opted in, maintained incrementally on import
 );
 
 -- For account lookup
@@ -91,7 +133,8 @@ CREATE INDEX IF NOT EXISTS asset_by_creator_addr_deleted ON asset(creator_addr,
 -- rewards pool) and account totals.
 CREATE TABLE IF NOT EXISTS metastate (
   k text primary key,
-  v jsonb
+  v jsonb,
+  version bigint NOT NULL DEFAULT 0 -- bumped on every write; lets a writer detect it lost a race via SetMetastateCAS
 );
 
 -- per app global state
@@ -99,10 +142,11 @@ CREATE TABLE IF NOT EXISTS metastate (
 CREATE TABLE IF NOT EXISTS app (
   index bigint PRIMARY KEY,
   creator bytea NOT NULL, -- account address
-  params jsonb NOT NULL, -- json string "null" iff app is deleted
+  params jsonb NOT NULL, -- retains the app's last-known parameters after it's deleted, for auditors that need its final state
   deleted bool NOT NULL, -- whether or not it is currently deleted
   created_at bigint NOT NULL, -- round that the asset was created
-  closed_at bigint -- round that the app was deleted; cannot be recreated because the index is unique
+  closed_at bigint, -- round that the app was deleted; cannot be recreated because the index is unique
+  closing_txid bytea -- txid of the transaction that destroyed the app, or NULL if it's still live or was destroyed by an inner transaction
 );
 
 -- For account lookup
@@ -129,4 +173,97 @@ CREATE TABLE IF NOT EXISTS app_box (
   value bytea NOT NULL, -- upon creation 'value' is 0x000...000 with length being the box'es size
   PRIMARY KEY (app, name)
 );
+
+-- rollup of every address that has ever called an app, so unique_callers
+-- below can be maintained incrementally instead of a COUNT(DISTINCT ...)
+-- over txn
+CREATE TABLE IF NOT EXISTS app_caller (
+  app bigint NOT NULL,
+  caller bytea NOT NULL,
+  PRIMARY KEY (app, caller)
+);
+
+-- per-application call statistics, maintained incrementally on import
+CREATE TABLE IF NOT EXISTS app_call_stats (
+  app bigint PRIMARY KEY,
+  call_count bigint NOT NULL DEFAULT 0,
+  unique_callers bigint NOT NULL DEFAULT 0
+);
+
+-- Rounds at which an account's participation keys were marked expired, per
+-- block_header.participationUpdates.expired-participation-accounts.
+CREATE TABLE IF NOT EXISTS account_participation_update (
+  addr bytea NOT NULL,
+  round bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_participation_update_addr ON account_participation_update ( addr, round DESC );
+
+-- Per-round global/local application state key deltas, populated only when
+-- EnableAppStateHistory is turned on, so state as of a past round can be
+-- reconstructed without needing an archival ledger. addr is NULL for a
+-- global state delta and the account's address for a local state delta.
+CREATE TABLE IF NOT EXISTS app_state_history (
+  round bigint NOT NULL,
+  intra integer NOT NULL,
+  app bigint NOT NULL,
+  addr bytea,
+  key bytea NOT NULL,
+  action smallint NOT NULL, -- 1=set bytes, 2=set uint, 3=delete; matches go-algorand-sdk's DeltaAction
+  value_bytes bytea,
+  value_uint numeric(20)
+);
+
+CREATE INDEX IF NOT EXISTS app_state_history_by_key ON app_state_history ( app, key, round DESC, intra DESC );
+
+-- Per-round account balance checkpoints, populated only when
+-- EnableBalanceHistory is turned on, one row per round in which an
+-- account's data was updated, so GET /v2/accounts/{addr}/balance-history can
+-- return a time series without clients reconstructing it from raw
+-- transactions.
+CREATE TABLE IF NOT EXISTS account_balance_history (
+  addr bytea NOT NULL,
+  round bigint NOT NULL,
+  microalgos bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_balance_history_by_addr ON account_balance_history ( addr, round DESC );
+
+-- Per-round account rewards totals, populated only when
+-- EnableRewardsHistory is turned on, one row per round in which an
+-- account's data was updated, so AccountRewards can
+-- summarize rewards earned over a round range without clients diffing
+-- transactions.
+CREATE TABLE IF NOT EXISTS account_reward_history (
+  addr bytea NOT NULL,
+  round bigint NOT NULL,
+  rewards_total bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_reward_history_by_addr ON account_reward_history ( addr, round DESC );
+
+-- Per-round total online stake, populated only when
+-- EnableOnlineStakeHistory is turned on, one row per round, so a time
+-- series of it can be served for governance and network-health dashboards
+-- without clients reconstructing it from account status changes.
+CREATE TABLE IF NOT EXISTS network_totals_history (
+  round bigint PRIMARY KEY,
+  online_stake bigint NOT NULL
+);
+
+-- Generic background-job bookkeeping: one row per named resumable
+-- maintenance task (pruning, rollups, backfills, ...), with a lease so at
+-- most one process works a job at a time, and a progress blob so a job can
+-- pick up where it left off instead of starting over after a restart.
+CREATE TABLE IF NOT EXISTS job (
+  name text PRIMARY KEY,
+  kind text NOT NULL,
+  status text NOT NULL DEFAULT 'idle',
+  progress jsonb NOT NULL DEFAULT '{}'::jsonb,
+  lease_owner text NOT NULL DEFAULT '',
+  lease_until timestamp without time zone NOT NULL DEFAULT 'epoch',
+  attempt integer NOT NULL DEFAULT 0,
+  last_error text,
+  updated_at timestamp without time zone NOT NULL DEFAULT now()
+);
 `
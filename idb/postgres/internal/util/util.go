@@ -93,8 +93,8 @@ func GetMetastate(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, key string)
 
 // SetMetastate sets metastate. If `tx` is nil, it uses a normal query.
 func SetMetastate(db *pgxpool.Pool, tx pgx.Tx, key, jsonStrValue string) error {
-	const setMetastateUpsert = `INSERT INTO metastate (k, v) VALUES ($1, $2)
-		ON CONFLICT (k) DO UPDATE SET v = EXCLUDED.v`
+	const setMetastateUpsert = `INSERT INTO metastate (k, v, version) VALUES ($1, $2, 1)
+		ON CONFLICT (k) DO UPDATE SET v = EXCLUDED.v, version = metastate.version + 1`
 
 	var err error
 	if tx == nil {
@@ -108,3 +108,64 @@ func SetMetastate(db *pgxpool.Pool, tx pgx.Tx, key, jsonStrValue string) error {
 
 	return nil
 }
+
+// ErrVersionConflict is returned by SetMetastateCAS when the row's version no
+// longer matches expectedVersion, because some other writer updated it first.
+// (Metastate rows are never deleted, so this is the only way a write can
+// lose a race.)
+var ErrVersionConflict = errors.New("metastate version conflict")
+
+// GetMetastateVersioned is like GetMetastate, but also returns the row's
+// current version, for a caller that intends to write it back with
+// SetMetastateCAS. A key that has never been written has an implicit version
+// of 0. Returns `idb.ErrorNotInitialized` if uninitialized.
+func GetMetastateVersioned(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, key string) (value string, version int64, err error) {
+	query := `SELECT v, version FROM metastate WHERE k = $1`
+
+	var row pgx.Row
+	if tx == nil {
+		row = db.QueryRow(ctx, query, key)
+	} else {
+		row = tx.QueryRow(ctx, query, key)
+	}
+
+	err = row.Scan(&value, &version)
+	if err == pgx.ErrNoRows {
+		return "", 0, idb.ErrorNotInitialized
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("GetMetastateVersioned() err: %w", err)
+	}
+
+	return value, version, nil
+}
+
+// SetMetastateCAS sets metastate for key to jsonStrValue, but only if the
+// row's version still matches expectedVersion (0 for a key that has never
+// been written), giving a caller optimistic concurrency control without
+// needing a serializable transaction: read the current value and version
+// with GetMetastateVersioned, compute the new value, then write it back here
+// with that version. If another writer updated key in between,
+// ErrVersionConflict is returned and the caller should re-read and retry. If
+// `tx` is nil, it uses a normal query.
+func SetMetastateCAS(db *pgxpool.Pool, tx pgx.Tx, key, jsonStrValue string, expectedVersion int64) error {
+	const setMetastateCASUpsert = `INSERT INTO metastate (k, v, version) VALUES ($1, $2, 1)
+		ON CONFLICT (k) DO UPDATE SET v = EXCLUDED.v, version = metastate.version + 1
+		WHERE metastate.version = $3`
+
+	var tag pgconn.CommandTag
+	var err error
+	if tx == nil {
+		tag, err = db.Exec(context.Background(), setMetastateCASUpsert, key, jsonStrValue, expectedVersion)
+	} else {
+		tag, err = tx.Exec(context.Background(), setMetastateCASUpsert, key, jsonStrValue, expectedVersion)
+	}
+	if err != nil {
+		return fmt.Errorf("SetMetastateCAS() err: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// appStateHistoryRow is one changed global or local state key queued for
+// app_state_history.
+type appStateHistoryRow struct {
+	intra uint64
+	app   uint64
+	addr  []byte
+	key   string
+	delta types.ValueDelta
+}
+
+// localStateDeltaAddress resolves a LocalDeltas key, an offset into
+// [txn.Sender, txn.Accounts[0], txn.Accounts[1], ...], to the account it
+// refers to.
+func localStateDeltaAddress(stxnad *types.SignedTxnWithAD, offset uint64) types.Address {
+	if offset == 0 {
+		return stxnad.Txn.Sender
+	}
+	return stxnad.Txn.Accounts[offset-1]
+}
+
+// collectAppStateDeltas appends one appStateHistoryRow per global or local
+// state key stxnad's EvalDelta changed, and recurses into its inner
+// transactions, which are assigned the intra offsets that follow stxnad's own.
+func collectAppStateDeltas(stxnad *types.SignedTxnWithAD, intra uint64, rows []appStateHistoryRow) []appStateHistoryRow {
+	appID := uint64(stxnad.Txn.ApplicationID)
+	if appID == 0 {
+		appID = uint64(stxnad.ApplyData.ApplicationID)
+	}
+	if appID != 0 {
+		for key, delta := range stxnad.ApplyData.EvalDelta.GlobalDelta {
+			rows = append(rows, appStateHistoryRow{intra: intra, app: appID, addr: nil, key: key, delta: delta})
+		}
+		for offset, localDelta := range stxnad.ApplyData.EvalDelta.LocalDeltas {
+			addr := localStateDeltaAddress(stxnad, offset)
+			for key, delta := range localDelta {
+				rows = append(rows, appStateHistoryRow{intra: intra, app: appID, addr: addr[:], key: key, delta: delta})
+			}
+		}
+	}
+
+	innerIntra := intra + 1
+	for i := range stxnad.ApplyData.EvalDelta.InnerTxns {
+		inner := &stxnad.ApplyData.EvalDelta.InnerTxns[i]
+		rows = collectAppStateDeltas(inner, innerIntra, rows)
+		innerIntra += countTransactionTree(inner)
+	}
+	return rows
+}
+
+// AddAppStateHistory writes one app_state_history row per application global
+// or local state key changed this round. Only called when
+// idb.IndexerDbOptions.EnableAppStateHistory is turned on.
+func AddAppStateHistory(block *types.Block, tx pgx.Tx) error {
+	var deltas []appStateHistoryRow
+
+	intra := uint64(0)
+	for _, stib := range block.Payset {
+		deltas = collectAppStateDeltas(&stib.SignedTxnWithAD, intra, deltas)
+		intra += countTransactionTree(&stib.SignedTxnWithAD)
+	}
+
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, len(deltas))
+	for i, d := range deltas {
+		var valueBytes []byte
+		var valueUint *uint64
+		switch d.delta.Action {
+		case types.SetBytesAction:
+			valueBytes = []byte(d.delta.Bytes)
+		case types.SetUintAction:
+			u := d.delta.Uint
+			valueUint = &u
+		}
+		rows[i] = []interface{}{
+			uint64(block.Round), d.intra, d.app, d.addr, []byte(d.key), int16(d.delta.Action), valueBytes, valueUint,
+		}
+	}
+
+	_, err := tx.CopyFrom(
+		context.Background(),
+		pgx.Identifier{"app_state_history"},
+		[]string{"round", "intra", "app", "addr", "key", "action", "value_bytes", "value_uint"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("addAppStateHistory() copy from err: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// AddParticipationUpdates writes one account_participation_update row for
+// every account the block's participationUpdates marks expired, so the
+// rounds at which a given account was marked expired can be looked up
+// directly instead of scanning every block_header.
+func AddParticipationUpdates(block *types.Block, tx pgx.Tx) error {
+	accounts := block.BlockHeader.ExpiredParticipationAccounts
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, len(accounts))
+	for i, addr := range accounts {
+		rows[i] = []interface{}{addr[:], uint64(block.Round)}
+	}
+
+	_, err := tx.CopyFrom(
+		context.Background(),
+		pgx.Identifier{"account_participation_update"},
+		[]string{"addr", "round"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("addParticipationUpdates() copy from err: %w", err)
+	}
+
+	return nil
+}
@@ -17,10 +17,11 @@ import (
 // Get the ID of the creatable referenced in the given transaction
 // (0 if not an asset or app transaction).
 // Note: ConsensusParams.MaxInnerTransactions could be overridden to force
-//       generating ApplyData.{ApplicationID/ConfigAsset}. This function does
-//       other things too, so it is not clear we should use it. The only
-//       real benefit is that it would slightly simplify this function by
-//       allowing us to leave out the intra / block parameters.
+//
+//	generating ApplyData.{ApplicationID/ConfigAsset}. This function does
+//	other things too, so it is not clear we should use it. The only
+//	real benefit is that it would slightly simplify this function by
+//	allowing us to leave out the intra / block parameters.
 func transactionAssetID(stxnad *types.SignedTxnWithAD, intra uint, block *types.Block) (uint64, error) {
 	assetid := uint64(0)
 	switch stxnad.Txn.Type {
@@ -61,6 +62,32 @@ func transactionAssetID(stxnad *types.SignedTxnWithAD, intra uint, block *types.
 	return assetid, nil
 }
 
+// notePrefix returns the first idb.NotePrefixLength bytes of note, or all of
+// it if shorter. It returns nil (stored as SQL NULL) for an empty note so
+// note-prefix search can distinguish "no note" from "note starting with
+// zero bytes".
+func notePrefix(note []byte) []byte {
+	if len(note) == 0 {
+		return nil
+	}
+	if len(note) > idb.NotePrefixLength {
+		return note[:idb.NotePrefixLength]
+	}
+	return note
+}
+
+// logicSigHash returns the LogicSig escrow address (i.e. the hash of its
+// program) for a transaction signed with a LogicSig, or nil if it wasn't.
+// Inner transactions are never independently LogicSig-signed, so this is
+// only ever called for root transactions.
+func logicSigHash(lsig *types.LogicSig) []byte {
+	if lsig.Blank() {
+		return nil
+	}
+	addr := crypto.LogicSigAddress(*lsig)
+	return addr[:]
+}
+
 // Traverses the inner transaction tree and writes database rows
 // to `outCh`. It performs a preorder traversal to correctly compute
 // the intra round offset, the offset for the next transaction is returned.
@@ -90,7 +117,10 @@ func yieldInnerTransactions(ctx context.Context, stxnad *types.SignedTxnWithAD,
 			uint64(block.Round), intra, int(typeenum), assetid,
 			nil, // inner transactions do not have a txid.
 			encoding.EncodeSignedTxnWithAD(txnNoInner),
-			encoding.EncodeTxnExtra(&extra)}
+			encoding.EncodeTxnExtra(&extra),
+			notePrefix(txn.Note),
+			nil, // inner transactions are never independently LogicSig-signed.
+			uint64(txn.Fee)}
 		select {
 		case <-ctx.Done():
 			return 0, fmt.Errorf("yieldInnerTransactions() ctx.Err(): %w", ctx.Err())
@@ -138,7 +168,10 @@ func yieldTransactions(ctx context.Context, block *types.Block, modifiedTxns []t
 		row := []interface{}{
 			uint64(block.Round), intra, int(typeenum), assetid, id,
 			encoding.EncodeSignedTxnWithAD(stxnad),
-			encoding.EncodeTxnExtra(&extra)}
+			encoding.EncodeTxnExtra(&extra),
+			notePrefix(txn.Note),
+			logicSigHash(&stxnad.Lsig),
+			uint64(txn.Fee)}
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("yieldTransactions() ctx.Err(): %w", ctx.Err())
@@ -171,7 +204,7 @@ func AddTransactions(block *types.Block, modifiedTxns []types.SignedTxnInBlock,
 	_, err1 := tx.CopyFrom(
 		context.Background(),
 		pgx.Identifier{"txn"},
-		[]string{"round", "intra", "typeenum", "asset", "txid", "txn", "extra"},
+		[]string{"round", "intra", "typeenum", "asset", "txid", "txn", "extra", "note_prefix", "lsig_hash", "fee"},
 		copyFromChannel(ch))
 	if err1 != nil {
 		// Exiting here will call `cancelFunc` which will cause the goroutine above to exit.
@@ -0,0 +1,69 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// multisigSubsigners returns the subsigner addresses of the multisig that
+// authorized stxnad, whether it signed directly or delegated via a LogicSig,
+// or nil if it wasn't multisig-authorized at all.
+func multisigSubsigners(stxnad *types.SignedTxnWithAD) []types.Address {
+	msig := stxnad.Msig
+	if msig.Blank() {
+		msig = stxnad.Lsig.Msig
+	}
+	if msig.Blank() {
+		return nil
+	}
+
+	addrs := make([]types.Address, 0, len(msig.Subsigs))
+	for _, sub := range msig.Subsigs {
+		var addr types.Address
+		copy(addr[:], sub.Key)
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// countTransactionTree returns the number of intra-round offsets that stxnad
+// and its inner transactions occupy, matching the preorder traversal used to
+// assign them in yieldTransactions/yieldInnerTransactions.
+func countTransactionTree(stxnad *types.SignedTxnWithAD) uint64 {
+	count := uint64(1)
+	for i := range stxnad.ApplyData.EvalDelta.InnerTxns {
+		count += countTransactionTree(&stxnad.ApplyData.EvalDelta.InnerTxns[i])
+	}
+	return count
+}
+
+// AddMultisigParticipation writes account participation info for multisig
+// (and delegated LogicSig multisig) subsigners to the
+// `txn_multisig_participation` table. Inner transactions are never
+// independently signed, so only root transactions are considered.
+func AddMultisigParticipation(block *types.Block, tx pgx.Tx) error {
+	var rows [][]interface{}
+
+	intra := uint64(0)
+	for _, stib := range block.Payset {
+		for _, addr := range multisigSubsigners(&stib.SignedTxnWithAD) {
+			rows = append(rows, []interface{}{addr[:], uint64(block.Round), intra})
+		}
+		intra += countTransactionTree(&stib.SignedTxnWithAD)
+	}
+
+	_, err := tx.CopyFrom(
+		context.Background(),
+		pgx.Identifier{"txn_multisig_participation"},
+		[]string{"addr", "round", "intra"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("addMultisigParticipation() copy from err: %w", err)
+	}
+
+	return nil
+}
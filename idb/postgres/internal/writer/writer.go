@@ -14,6 +14,7 @@ import (
 	"github.com/algorand/indexer/idb/postgres/internal/schema"
 	"github.com/algorand/indexer/types"
 
+	"github.com/algorand/go-algorand-sdk/crypto"
 	sdk "github.com/algorand/go-algorand-sdk/types"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions/logic"
@@ -21,23 +22,29 @@ import (
 )
 
 const (
-	addBlockHeaderStmtName             = "add_block_header"
-	setSpecialAccountsStmtName         = "set_special_accounts"
-	upsertAssetStmtName                = "upsert_asset"
-	upsertAccountAssetStmtName         = "upsert_account_asset"
-	upsertAppStmtName                  = "upsert_app"
-	upsertAccountAppStmtName           = "upsert_account_app"
-	deleteAccountStmtName              = "delete_account"
-	deleteAccountUpdateKeytypeStmtName = "delete_account_update_keytype"
-	upsertAccountStmtName              = "upsert_account"
-	upsertAccountWithKeytypeStmtName   = "upsert_account_with_keytype"
-	deleteAssetStmtName                = "delete_asset"
-	deleteAccountAssetStmtName         = "delete_account_asset"
-	deleteAppStmtName                  = "delete_app"
-	deleteAccountAppStmtName           = "delete_account_app"
-	upsertAppBoxStmtName               = "upsert_app_box"
-	deleteAppBoxStmtName               = "delete_app_box"
-	updateAccountTotalsStmtName        = "update_account_totals"
+	addBlockHeaderStmtName               = "add_block_header"
+	setSpecialAccountsStmtName           = "set_special_accounts"
+	upsertAssetStmtName                  = "upsert_asset"
+	upsertAppStmtName                    = "upsert_app"
+	deleteAccountStmtName                = "delete_account"
+	deleteAccountUpdateKeytypeStmtName   = "delete_account_update_keytype"
+	upsertAccountStmtName                = "upsert_account"
+	upsertAccountWithKeytypeStmtName     = "upsert_account_with_keytype"
+	deleteAssetStmtName                  = "delete_asset"
+	deleteAppStmtName                    = "delete_app"
+	upsertAppBoxStmtName                 = "upsert_app_box"
+	deleteAppBoxStmtName                 = "delete_app_box"
+	updateAccountTotalsStmtName          = "update_account_totals"
+	upsertAccountAssetBatchStmtName      = "upsert_account_asset_batch"
+	deleteAccountAssetBatchStmtName      = "delete_account_asset_batch"
+	upsertAccountAppBatchStmtName        = "upsert_account_app_batch"
+	deleteAccountAppBatchStmtName        = "delete_account_app_batch"
+	updateAccountActivityBatchStmtName   = "update_account_activity_batch"
+	updateAccountHeartbeatsBatchStmtName = "update_account_heartbeats_batch"
+	updateAppCallStatsBatchStmtName      = "update_app_call_stats_batch"
+	insertAccountBalanceHistoryStmtName  = "insert_account_balance_history"
+	insertAccountRewardHistoryStmtName   = "insert_account_reward_history"
+	insertNetworkTotalsHistoryStmtName   = "insert_network_totals_history"
 )
 
 var statements = map[string]string{
@@ -51,18 +58,10 @@ var statements = map[string]string{
 		(index, creator_addr, params, deleted, created_at)
 		VALUES($1, $2, $3, FALSE, $4) ON CONFLICT (index) DO UPDATE SET
 		creator_addr = EXCLUDED.creator_addr, params = EXCLUDED.params, deleted = FALSE`,
-	upsertAccountAssetStmtName: `INSERT INTO account_asset
-		(addr, assetid, amount, frozen, deleted, created_at)
-		VALUES($1, $2, $3, $4, FALSE, $5) ON CONFLICT (addr, assetid) DO UPDATE SET
-		amount = EXCLUDED.amount, frozen = EXCLUDED.frozen, deleted = FALSE`,
 	upsertAppStmtName: `INSERT INTO app
 		(index, creator, params, deleted, created_at)
 		VALUES($1, $2, $3, FALSE, $4) ON CONFLICT (index) DO UPDATE SET
 		creator = EXCLUDED.creator, params = EXCLUDED.params, deleted = FALSE`,
-	upsertAccountAppStmtName: `INSERT INTO account_app
-		(addr, app, localstate, deleted, created_at)
-		VALUES($1, $2, $3, FALSE, $4) ON CONFLICT (addr, app) DO UPDATE SET
-		localstate = EXCLUDED.localstate, deleted = FALSE`,
 	deleteAccountStmtName: `INSERT INTO account
 		(addr, microalgos, rewardsbase, rewards_total, deleted, created_at, closed_at,
 		 account_data)
@@ -91,24 +90,22 @@ var statements = map[string]string{
 		microalgos = EXCLUDED.microalgos, rewardsbase = EXCLUDED.rewardsbase,
 		rewards_total = EXCLUDED.rewards_total, deleted = FALSE, keytype = EXCLUDED.keytype,
 		account_data = EXCLUDED.account_data`,
+	// params is intentionally left out of the ON CONFLICT UPDATE SET clause
+	// below: it retains whatever was last written for this asset instead of
+	// being overwritten, so an include-all lookup can still report a
+	// destroyed asset's last-known parameters.
 	deleteAssetStmtName: `INSERT INTO asset
-		(index, creator_addr, params, deleted, created_at, closed_at)
-		VALUES($1, $2, 'null'::jsonb, TRUE, $3, $3) ON CONFLICT (index) DO UPDATE SET
-		creator_addr = EXCLUDED.creator_addr, params = EXCLUDED.params, deleted = TRUE,
-		closed_at = EXCLUDED.closed_at`,
-	deleteAccountAssetStmtName: `INSERT INTO account_asset
-		(addr, assetid, amount, frozen, deleted, created_at, closed_at)
-		VALUES($1, $2, 0, false, TRUE, $3, $3) ON CONFLICT (addr, assetid) DO UPDATE SET
-		amount = EXCLUDED.amount, deleted = TRUE, closed_at = EXCLUDED.closed_at`,
+		(index, creator_addr, params, deleted, created_at, closed_at, closing_txid)
+		VALUES($1, $2, 'null'::jsonb, TRUE, $3, $3, $4) ON CONFLICT (index) DO UPDATE SET
+		creator_addr = EXCLUDED.creator_addr, deleted = TRUE,
+		closed_at = EXCLUDED.closed_at, closing_txid = EXCLUDED.closing_txid`,
+	// params is intentionally left out of the ON CONFLICT UPDATE SET clause
+	// below, for the same reason as deleteAssetStmtName above.
 	deleteAppStmtName: `INSERT INTO app
-		(index, creator, params, deleted, created_at, closed_at)
-		VALUES($1, $2, 'null'::jsonb, TRUE, $3, $3) ON CONFLICT (index) DO UPDATE SET
-		creator = EXCLUDED.creator, params = EXCLUDED.params, deleted = TRUE,
-		closed_at = EXCLUDED.closed_at`,
-	deleteAccountAppStmtName: `INSERT INTO account_app
-		(addr, app, localstate, deleted, created_at, closed_at)
-		VALUES($1, $2, 'null'::jsonb, TRUE, $3, $3) ON CONFLICT (addr, app) DO UPDATE SET
-		localstate = EXCLUDED.localstate, deleted = TRUE, closed_at = EXCLUDED.closed_at`,
+		(index, creator, params, deleted, created_at, closed_at, closing_txid)
+		VALUES($1, $2, 'null'::jsonb, TRUE, $3, $3, $4) ON CONFLICT (index) DO UPDATE SET
+		creator = EXCLUDED.creator, deleted = TRUE,
+		closed_at = EXCLUDED.closed_at, closing_txid = EXCLUDED.closing_txid`,
 	upsertAppBoxStmtName: `INSERT INTO app_box AS ab
 		(app, name, value)
 		VALUES ($1, $2, $3)
@@ -117,11 +114,216 @@ var statements = map[string]string{
 	deleteAppBoxStmtName: `DELETE FROM app_box WHERE app = $1 and name = $2`,
 	updateAccountTotalsStmtName: `UPDATE metastate SET v = $1 WHERE k = '` +
 		schema.AccountTotals + `'`,
+	// The batch statements below write a round's account_asset/account_app
+	// changes as a single multi-row UPSERT/DELETE statement per round, using
+	// unnest() to turn equal-length parameter arrays into rows. Busy rounds
+	// touch thousands of account_asset/account_app rows, so this cuts commit
+	// time by avoiding one round-trip and one planner pass per row.
+	// Holder counts are maintained in the same statement as the upsert/delete
+	// by comparing against each row's pre-existing deleted state (via a LEFT
+	// JOIN/JOIN against account_asset before the INSERT touches it), so a
+	// round can't be left with a stale asset.num_holders if it crashes
+	// partway through.
+	upsertAccountAssetBatchStmtName: `WITH data AS (
+		SELECT addr, assetid, amount, frozen, round
+		FROM unnest($1::bytea[], $2::bigint[], $3::numeric[], $4::bool[], $5::bigint[])
+		AS t(addr, assetid, amount, frozen, round)
+	), prior AS (
+		SELECT d.assetid, (aa.addr IS NULL OR aa.deleted) AS new_holder
+		FROM data d LEFT JOIN account_asset aa ON aa.addr = d.addr AND aa.assetid = d.assetid
+	), upserted AS (
+		INSERT INTO account_asset
+		(addr, assetid, amount, frozen, deleted, created_at)
+		SELECT addr, assetid, amount, frozen, FALSE, round FROM data
+		ON CONFLICT (addr, assetid) DO UPDATE SET
+		amount = EXCLUDED.amount, frozen = EXCLUDED.frozen, deleted = FALSE
+	), deltas AS (
+		SELECT assetid, count(*) FILTER (WHERE new_holder) AS delta FROM prior GROUP BY assetid
+	)
+	UPDATE asset SET num_holders = num_holders + deltas.delta
+	FROM deltas WHERE asset.index = deltas.assetid`,
+	deleteAccountAssetBatchStmtName: `WITH data AS (
+		SELECT addr, assetid, round
+		FROM unnest($1::bytea[], $2::bigint[], $3::bigint[]) AS t(addr, assetid, round)
+	), prior AS (
+		SELECT d.assetid, (aa.addr IS NOT NULL AND NOT aa.deleted) AS was_holder
+		FROM data d JOIN account_asset aa ON aa.addr = d.addr AND aa.assetid = d.assetid
+	), deleted AS (
+		INSERT INTO account_asset
+		(addr, assetid, amount, frozen, deleted, created_at, closed_at)
+		SELECT addr, assetid, 0, false, TRUE, round, round FROM data
+		ON CONFLICT (addr, assetid) DO UPDATE SET
+		amount = EXCLUDED.amount, deleted = TRUE, closed_at = EXCLUDED.closed_at
+	), deltas AS (
+		SELECT assetid, count(*) FILTER (WHERE was_holder) AS delta FROM prior GROUP BY assetid
+	)
+	UPDATE asset SET num_holders = num_holders - deltas.delta
+	FROM deltas WHERE asset.index = deltas.assetid`,
+	upsertAccountAppBatchStmtName: `INSERT INTO account_app
+		(addr, app, localstate, deleted, created_at)
+		SELECT addr, app, localstate, FALSE, round
+		FROM unnest($1::bytea[], $2::bigint[], $3::jsonb[], $4::bigint[]) AS t(addr, app, localstate, round)
+		ON CONFLICT (addr, app) DO UPDATE SET
+		localstate = EXCLUDED.localstate, deleted = FALSE`,
+	deleteAccountAppBatchStmtName: `INSERT INTO account_app
+		(addr, app, localstate, deleted, created_at, closed_at)
+		SELECT addr, app, 'null'::jsonb, TRUE, round, round
+		FROM unnest($1::bytea[], $2::bigint[], $3::bigint[]) AS t(addr, app, round)
+		ON CONFLICT (addr, app) DO UPDATE SET
+		localstate = EXCLUDED.localstate, deleted = TRUE, closed_at = EXCLUDED.closed_at`,
+	// Queued after the account upserts/deletes above in the same batch, so a
+	// brand new account's row already exists by the time this runs.
+	updateAccountActivityBatchStmtName: `UPDATE account SET
+		total_transactions = total_transactions + t.txn_count,
+		last_active_round = $1
+		FROM unnest($2::bytea[], $3::bigint[]) AS t(addr, txn_count)
+		WHERE account.addr = t.addr`,
+	// Queued after the account upserts/deletes above in the same batch, for
+	// the same reason as updateAccountActivityBatchStmtName.
+	updateAccountHeartbeatsBatchStmtName: `UPDATE account SET
+		last_heartbeat_round = $1
+		FROM unnest($2::bytea[]) AS t(addr)
+		WHERE account.addr = t.addr`,
+	// unique_callers is maintained via app_caller, a rollup table of every
+	// (app, caller) pair ever seen: inserting into it with ON CONFLICT DO
+	// NOTHING only returns the rows that were actually new, so counting the
+	// RETURNING set gives the number of callers an app hasn't seen before,
+	// with no separate read-then-write race.
+	updateAppCallStatsBatchStmtName: `WITH calls AS (
+		SELECT app, caller FROM unnest($1::bigint[], $2::bytea[]) AS t(app, caller)
+	), call_counts AS (
+		SELECT app, count(*) AS calls FROM calls GROUP BY app
+	), new_callers AS (
+		INSERT INTO app_caller (app, caller)
+		SELECT DISTINCT app, caller FROM calls
+		ON CONFLICT DO NOTHING
+		RETURNING app
+	), new_caller_counts AS (
+		SELECT app, count(*) AS new_callers FROM new_callers GROUP BY app
+	)
+	INSERT INTO app_call_stats (app, call_count, unique_callers)
+	SELECT cc.app, cc.calls, COALESCE(ncc.new_callers, 0)
+	FROM call_counts cc LEFT JOIN new_caller_counts ncc ON cc.app = ncc.app
+	ON CONFLICT (app) DO UPDATE SET
+	call_count = app_call_stats.call_count + EXCLUDED.call_count,
+	unique_callers = app_call_stats.unique_callers + EXCLUDED.unique_callers`,
+	insertAccountBalanceHistoryStmtName: `INSERT INTO account_balance_history
+		(addr, round, microalgos)
+		VALUES ($1, $2, $3)`,
+	insertAccountRewardHistoryStmtName: `INSERT INTO account_reward_history
+		(addr, round, rewards_total)
+		VALUES ($1, $2, $3)`,
+	insertNetworkTotalsHistoryStmtName: `INSERT INTO network_totals_history
+		(round, online_stake)
+		VALUES ($1, $2)`,
 }
 
 // Writer is responsible for writing blocks and accounting state deltas to the database.
 type Writer struct {
 	tx pgx.Tx
+
+	// skipSpecialAccounts, when true, tells AddBlock to skip re-upserting the
+	// special accounts metastate row. Callers set this via
+	// SetSkipSpecialAccounts when they've determined the fee sink, rewards
+	// pool, and consensus version haven't changed since the previous round.
+	// It defaults to false, so AddBlock writes the row unless told otherwise.
+	skipSpecialAccounts bool
+
+	// enableBalanceHistory, when true, tells writeAccount to also queue an
+	// account_balance_history row for every account in this round's account
+	// deltas. That covers every account whose balance changed, and can also
+	// repeat an unchanged balance for an account whose other data changed. Callers set this via SetEnableBalanceHistory from
+	// idb.IndexerDbOptions.EnableBalanceHistory. It defaults to false, since
+	// it adds a write per changed balance per round.
+	enableBalanceHistory bool
+
+	// enableRewardsHistory, when true, tells writeAccount to also queue an
+	// account_reward_history row for every account in this round's account
+	// deltas, whether or not its rewards total changed. Callers set this via SetEnableRewardsHistory from
+	// idb.IndexerDbOptions.EnableRewardsHistory. It defaults to false, since
+	// it adds a write per changed rewards total per round.
+	enableRewardsHistory bool
+
+	// enableOnlineStakeHistory, when true, tells AddBlock to also queue a
+	// network_totals_history row recording the round's total online stake.
+	// Callers set this via SetEnableOnlineStakeHistory from
+	// idb.IndexerDbOptions.EnableOnlineStakeHistory. It defaults to false,
+	// since it adds a write per round.
+	enableOnlineStakeHistory bool
+
+	// maxBatchStatements bounds how many statements AddBlock queues into a
+	// single pgx.Batch before flushing it to tx, so a round with thousands
+	// of account/asset/app changes doesn't build one unbounded batch in
+	// memory and on the wire. Callers set this via SetMaxBatchStatements
+	// from idb.IndexerDbOptions.MaxBatchStatements. Zero means
+	// defaultMaxBatchStatements.
+	maxBatchStatements int
+}
+
+// defaultMaxBatchStatements is used in place of a zero
+// Writer.maxBatchStatements.
+const defaultMaxBatchStatements = 5000
+
+// batchQueuer is implemented by both pgx.Batch and chunkedBatch, so the
+// per-statement write helpers below don't need to know whether they're
+// filling an unbounded batch (AddBlock0, which is always small) or one
+// that's flushed to Postgres in bounded-size chunks (AddBlock).
+type batchQueuer interface {
+	Queue(query string, arguments ...interface{}) *pgx.QueuedQuery
+}
+
+// chunkedBatch wraps pgx.Batch so a round's accounting writes are sent to tx
+// in bounded-size chunks instead of accumulating into one arbitrarily large
+// batch. Every chunk still runs inside the caller's transaction, so the
+// round only becomes visible to other transactions when that transaction
+// commits: chunking bounds the size of each individual round-trip, it
+// doesn't change the round's all-or-nothing visibility.
+type chunkedBatch struct {
+	tx      pgx.Tx
+	maxSize int
+	batch   pgx.Batch
+	err     error
+}
+
+func makeChunkedBatch(tx pgx.Tx, maxSize int) chunkedBatch {
+	return chunkedBatch{tx: tx, maxSize: maxSize}
+}
+
+// Queue is part of the batchQueuer interface. Once maxSize statements have
+// accumulated, it flushes them to tx before returning.
+func (b *chunkedBatch) Queue(query string, arguments ...interface{}) *pgx.QueuedQuery {
+	if b.err != nil {
+		return nil
+	}
+	qq := b.batch.Queue(query, arguments...)
+	if b.maxSize > 0 && b.batch.Len() >= b.maxSize {
+		b.sendBatch()
+	}
+	return qq
+}
+
+func (b *chunkedBatch) sendBatch() {
+	if b.batch.Len() == 0 {
+		return
+	}
+	n := b.batch.Len()
+	results := b.tx.SendBatch(context.Background(), &b.batch)
+	for i := 0; i < n; i++ {
+		if _, err := results.Exec(); err != nil && b.err == nil {
+			b.err = fmt.Errorf("chunkedBatch exec err: %w", err)
+		}
+	}
+	if err := results.Close(); err != nil && b.err == nil {
+		b.err = fmt.Errorf("chunkedBatch close err: %w", err)
+	}
+	b.batch = pgx.Batch{}
+}
+
+// Flush sends any statements queued since the last automatic flush, and
+// returns the first error encountered by any chunk sent so far.
+func (b *chunkedBatch) Flush() error {
+	b.sendBatch()
+	return b.err
 }
 
 // MakeWriter creates a Writer object.
@@ -140,6 +342,43 @@ func MakeWriter(tx pgx.Tx) (Writer, error) {
 	return w, nil
 }
 
+// SetSkipSpecialAccounts tells AddBlock whether to skip re-upserting the
+// special accounts metastate row for the next call. It has no effect on
+// AddBlock0, which always writes the row since it establishes the initial
+// value. Callers are responsible for detecting whether the fee sink, rewards
+// pool, and consensus version actually changed since the previous round.
+func (w *Writer) SetSkipSpecialAccounts(skip bool) {
+	w.skipSpecialAccounts = skip
+}
+
+// SetEnableBalanceHistory tells AddBlock whether to also record an
+// account_balance_history row for every account in the round's account
+// deltas, for the next call.
+func (w *Writer) SetEnableBalanceHistory(enable bool) {
+	w.enableBalanceHistory = enable
+}
+
+// SetEnableRewardsHistory tells AddBlock whether to also record an
+// account_reward_history row for every account in the round's account
+// deltas, for the next call.
+func (w *Writer) SetEnableRewardsHistory(enable bool) {
+	w.enableRewardsHistory = enable
+}
+
+// SetEnableOnlineStakeHistory tells AddBlock whether to also record a
+// network_totals_history row with the round's total online stake, for the
+// next call.
+func (w *Writer) SetEnableOnlineStakeHistory(enable bool) {
+	w.enableOnlineStakeHistory = enable
+}
+
+// SetMaxBatchStatements tells AddBlock how many statements to queue into a
+// single batch before flushing it to the database, for the next call. Zero
+// resets it to defaultMaxBatchStatements.
+func (w *Writer) SetMaxBatchStatements(max int) {
+	w.maxBatchStatements = max
+}
+
 // Close shuts down Writer.
 func (w *Writer) Close() {
 	for name := range statements {
@@ -147,14 +386,14 @@ func (w *Writer) Close() {
 	}
 }
 
-func addBlockHeader(blockHeader *sdk.BlockHeader, batch *pgx.Batch) {
+func addBlockHeader(blockHeader *sdk.BlockHeader, batch batchQueuer) {
 	batch.Queue(
 		addBlockHeaderStmtName,
 		uint64(blockHeader.Round), time.Unix(blockHeader.TimeStamp, 0).UTC(),
 		blockHeader.RewardsLevel, encoding.EncodeBlockHeader(*blockHeader))
 }
 
-func setSpecialAccounts(addresses types.SpecialAddresses, batch *pgx.Batch) {
+func setSpecialAccounts(addresses types.SpecialAddresses, batch batchQueuer) {
 	j := encoding.EncodeSpecialAddresses(addresses)
 	batch.Queue(setSpecialAccountsStmtName, j)
 }
@@ -189,7 +428,7 @@ type optionalSigTypeDelta struct {
 	value   sigTypeDelta
 }
 
-func writeAccount(round sdk.Round, address basics.Address, accountData ledgercore.AccountData, sigtypeDelta optionalSigTypeDelta, batch *pgx.Batch) {
+func writeAccount(round sdk.Round, address basics.Address, accountData ledgercore.AccountData, sigtypeDelta optionalSigTypeDelta, enableBalanceHistory bool, enableRewardsHistory bool, batch batchQueuer) {
 	sigtypeFunc := func(delta sigTypeDelta) *idb.SigType {
 		if !delta.present {
 			return nil
@@ -200,6 +439,8 @@ func writeAccount(round sdk.Round, address basics.Address, accountData ledgercor
 		return res
 	}
 
+	var microalgos uint64
+	var rewardsTotal uint64
 	if accountData.IsZero() {
 		// Delete account.
 		if sigtypeDelta.present {
@@ -213,6 +454,8 @@ func writeAccount(round sdk.Round, address basics.Address, accountData ledgercor
 		// Update account.
 		accountDataJSON :=
 			encoding.EncodeTrimmedLcAccountData(encoding.TrimLcAccountData(accountData))
+		microalgos = accountData.MicroAlgos.Raw
+		rewardsTotal = accountData.RewardedMicroAlgos.Raw
 
 		if sigtypeDelta.present {
 			batch.Queue(
@@ -228,11 +471,197 @@ func writeAccount(round sdk.Round, address basics.Address, accountData ledgercor
 				accountDataJSON)
 		}
 	}
+
+	if enableBalanceHistory {
+		batch.Queue(insertAccountBalanceHistoryStmtName, address[:], uint64(round), microalgos)
+	}
+	if enableRewardsHistory {
+		batch.Queue(insertAccountRewardHistoryStmtName, address[:], uint64(round), rewardsTotal)
+	}
+}
+
+// updateAccountActivity increments total_transactions and refreshes
+// last_active_round for every address that sent, received, or was otherwise
+// referenced by a transaction (including inner transactions) this round.
+// It must be queued after this round's account upserts/deletes in the same
+// batch: a brand new account's row doesn't exist until that upsert runs.
+func updateAccountActivity(round sdk.Round, payset []sdk.SignedTxnInBlock, batch batchQueuer) {
+	counts := make(map[sdk.Address]uint64)
+	for i := range payset {
+		for _, addr := range getTransactionParticipants(&payset[i].SignedTxnWithAD, true) {
+			counts[addr]++
+		}
+	}
+
+	if len(counts) == 0 {
+		return
+	}
+
+	addrs := make([][]byte, 0, len(counts))
+	txnCounts := make([]uint64, 0, len(counts))
+	for addr, count := range counts {
+		address := addr
+		addrs = append(addrs, address[:])
+		txnCounts = append(txnCounts, count)
+	}
+
+	batch.Queue(updateAccountActivityBatchStmtName, uint64(round), addrs, txnCounts)
+}
+
+// updateAccountHeartbeats refreshes last_heartbeat_round for every address
+// that sent a key registration transaction this round. Key registration
+// transactions are the only available proxy for "this account is actively
+// participating online" in the imported data: block proposer identity isn't
+// part of it, and keyreg transactions cannot occur as inner transactions, so
+// unlike updateAppCallStats there's no need to recurse into the payset.
+// It must be queued after this round's account upserts/deletes in the same
+// batch, for the same reason as updateAccountActivity.
+func updateAccountHeartbeats(round sdk.Round, payset []sdk.SignedTxnInBlock, batch batchQueuer) {
+	var addrs [][]byte
+	for i := range payset {
+		txn := &payset[i].SignedTxnWithAD.Txn
+		if txn.Type == sdk.KeyRegistrationTx {
+			sender := txn.Sender
+			addrs = append(addrs, sender[:])
+		}
+	}
+
+	if len(addrs) == 0 {
+		return
+	}
+
+	batch.Queue(updateAccountHeartbeatsBatchStmtName, uint64(round), addrs)
+}
+
+// appCall pairs an application ID with the address that invoked it.
+type appCall struct {
+	appID  uint64
+	caller sdk.Address
+}
+
+// collectAppCalls returns one entry per application call found in stxnad,
+// recursing into inner transactions so nested app calls are counted against
+// the app they actually invoked.
+func collectAppCalls(stxnad *sdk.SignedTxnWithAD) []appCall {
+	var calls []appCall
+	if stxnad.Txn.Type == sdk.ApplicationCallTx {
+		appID := uint64(stxnad.Txn.ApplicationID)
+		if appID == 0 {
+			appID = uint64(stxnad.ApplyData.ApplicationID)
+		}
+		if appID != 0 {
+			calls = append(calls, appCall{appID: appID, caller: stxnad.Txn.Sender})
+		}
+	}
+	for i := range stxnad.ApplyData.EvalDelta.InnerTxns {
+		calls = append(calls, collectAppCalls(&stxnad.ApplyData.EvalDelta.InnerTxns[i])...)
+	}
+	return calls
 }
 
-func writeAssetResource(round sdk.Round, resource *ledgercore.AssetResourceRecord, batch *pgx.Batch) {
+// updateAppCallStats increments app_call_stats.call_count for every
+// application call this round (including inner app calls), and
+// unique_callers for every (app, caller) pair not already recorded in the
+// app_caller rollup table.
+func updateAppCallStats(payset []sdk.SignedTxnInBlock, batch batchQueuer) {
+	var apps []uint64
+	var callers [][]byte
+	for i := range payset {
+		for _, call := range collectAppCalls(&payset[i].SignedTxnWithAD) {
+			caller := call.caller
+			apps = append(apps, call.appID)
+			callers = append(callers, caller[:])
+		}
+	}
+
+	if len(apps) == 0 {
+		return
+	}
+
+	batch.Queue(updateAppCallStatsBatchStmtName, apps, callers)
+}
+
+// accountAssetBatch accumulates account_asset row changes for a round so
+// they can be written as a single multi-row statement instead of one
+// statement per address/asset pair.
+type accountAssetBatch struct {
+	upsertAddr   [][]byte
+	upsertAsset  []uint64
+	upsertAmount []string
+	upsertFrozen []bool
+	upsertRound  []uint64
+
+	deleteAddr  [][]byte
+	deleteAsset []uint64
+	deleteRound []uint64
+}
+
+func (b *accountAssetBatch) upsert(addr []byte, assetID uint64, amount string, frozen bool, round sdk.Round) {
+	b.upsertAddr = append(b.upsertAddr, addr)
+	b.upsertAsset = append(b.upsertAsset, assetID)
+	b.upsertAmount = append(b.upsertAmount, amount)
+	b.upsertFrozen = append(b.upsertFrozen, frozen)
+	b.upsertRound = append(b.upsertRound, uint64(round))
+}
+
+func (b *accountAssetBatch) delete(addr []byte, assetID uint64, round sdk.Round) {
+	b.deleteAddr = append(b.deleteAddr, addr)
+	b.deleteAsset = append(b.deleteAsset, assetID)
+	b.deleteRound = append(b.deleteRound, uint64(round))
+}
+
+func (b *accountAssetBatch) queue(batch batchQueuer) {
+	if len(b.upsertAddr) > 0 {
+		batch.Queue(
+			upsertAccountAssetBatchStmtName,
+			b.upsertAddr, b.upsertAsset, b.upsertAmount, b.upsertFrozen, b.upsertRound)
+	}
+	if len(b.deleteAddr) > 0 {
+		batch.Queue(deleteAccountAssetBatchStmtName, b.deleteAddr, b.deleteAsset, b.deleteRound)
+	}
+}
+
+// accountAppBatch accumulates account_app row changes for a round so they
+// can be written as a single multi-row statement instead of one statement
+// per address/app pair.
+type accountAppBatch struct {
+	upsertAddr      [][]byte
+	upsertApp       []uint64
+	upsertLocalData [][]byte
+	upsertRound     []uint64
+
+	deleteAddr  [][]byte
+	deleteApp   []uint64
+	deleteRound []uint64
+}
+
+func (b *accountAppBatch) upsert(addr []byte, appID uint64, localState []byte, round sdk.Round) {
+	b.upsertAddr = append(b.upsertAddr, addr)
+	b.upsertApp = append(b.upsertApp, appID)
+	b.upsertLocalData = append(b.upsertLocalData, localState)
+	b.upsertRound = append(b.upsertRound, uint64(round))
+}
+
+func (b *accountAppBatch) delete(addr []byte, appID uint64, round sdk.Round) {
+	b.deleteAddr = append(b.deleteAddr, addr)
+	b.deleteApp = append(b.deleteApp, appID)
+	b.deleteRound = append(b.deleteRound, uint64(round))
+}
+
+func (b *accountAppBatch) queue(batch batchQueuer) {
+	if len(b.upsertAddr) > 0 {
+		batch.Queue(
+			upsertAccountAppBatchStmtName,
+			b.upsertAddr, b.upsertApp, b.upsertLocalData, b.upsertRound)
+	}
+	if len(b.deleteAddr) > 0 {
+		batch.Queue(deleteAccountAppBatchStmtName, b.deleteAddr, b.deleteApp, b.deleteRound)
+	}
+}
+
+func writeAssetResource(round sdk.Round, resource *ledgercore.AssetResourceRecord, closingTxids map[uint64]string, assetAccounts *accountAssetBatch, batch batchQueuer) {
 	if resource.Params.Deleted {
-		batch.Queue(deleteAssetStmtName, resource.Aidx, resource.Addr[:], round)
+		batch.Queue(deleteAssetStmtName, resource.Aidx, resource.Addr[:], round, closingTxidBytes(closingTxids, uint64(resource.Aidx)))
 	} else {
 		if resource.Params.Params != nil {
 			batch.Queue(
@@ -243,20 +672,20 @@ func writeAssetResource(round sdk.Round, resource *ledgercore.AssetResourceRecor
 	}
 
 	if resource.Holding.Deleted {
-		batch.Queue(deleteAccountAssetStmtName, resource.Addr[:], resource.Aidx, round)
+		assetAccounts.delete(resource.Addr[:], resource.Aidx, round)
 	} else {
 		if resource.Holding.Holding != nil {
-			batch.Queue(
-				upsertAccountAssetStmtName, resource.Addr[:], resource.Aidx,
+			assetAccounts.upsert(
+				resource.Addr[:], resource.Aidx,
 				strconv.FormatUint(resource.Holding.Holding.Amount, 10),
 				resource.Holding.Holding.Frozen, round)
 		}
 	}
 }
 
-func writeAppResource(round sdk.Round, resource *ledgercore.AppResourceRecord, batch *pgx.Batch) {
+func writeAppResource(round sdk.Round, resource *ledgercore.AppResourceRecord, closingTxids map[uint64]string, appAccounts *accountAppBatch, batch batchQueuer) {
 	if resource.Params.Deleted {
-		batch.Queue(deleteAppStmtName, resource.Aidx, resource.Addr[:], round)
+		batch.Queue(deleteAppStmtName, resource.Aidx, resource.Addr[:], round, closingTxidBytes(closingTxids, uint64(resource.Aidx)))
 	} else {
 		if resource.Params.Params != nil {
 			batch.Queue(
@@ -266,17 +695,17 @@ func writeAppResource(round sdk.Round, resource *ledgercore.AppResourceRecord, b
 	}
 
 	if resource.State.Deleted {
-		batch.Queue(deleteAccountAppStmtName, resource.Addr[:], resource.Aidx, round)
+		appAccounts.delete(resource.Addr[:], resource.Aidx, round)
 	} else {
 		if resource.State.LocalState != nil {
-			batch.Queue(
-				upsertAccountAppStmtName, resource.Addr[:], resource.Aidx,
+			appAccounts.upsert(
+				resource.Addr[:], resource.Aidx,
 				encoding.EncodeAppLocalState(*resource.State.LocalState), round)
 		}
 	}
 }
 
-func writeAccountDeltas(round sdk.Round, accountDeltas *ledgercore.AccountDeltas, sigtypeDeltas map[sdk.Address]sigTypeDelta, batch *pgx.Batch) {
+func writeAccountDeltas(round sdk.Round, accountDeltas *ledgercore.AccountDeltas, sigtypeDeltas map[sdk.Address]sigTypeDelta, closingAssetTxids map[uint64]string, closingAppTxids map[uint64]string, enableBalanceHistory bool, enableRewardsHistory bool, batch batchQueuer) {
 	// Update `account` table.
 	for i := 0; i < accountDeltas.Len(); i++ {
 
@@ -285,28 +714,77 @@ func writeAccountDeltas(round sdk.Round, accountDeltas *ledgercore.AccountDeltas
 		var sigtypeDelta optionalSigTypeDelta
 		sigtypeDelta.value, sigtypeDelta.present = sigtypeDeltas[sdk.Address(address)]
 
-		writeAccount(round, address, accountData, sigtypeDelta, batch)
+		writeAccount(round, address, accountData, sigtypeDelta, enableBalanceHistory, enableRewardsHistory, batch)
 	}
 
-	// Update `asset` and `account_asset` tables.
+	// Update `asset` and `account_asset` tables. Holding changes are
+	// coalesced into a single multi-row statement below instead of one
+	// UPSERT/DELETE per holding.
 	{
+		var assetAccounts accountAssetBatch
 		assetResources := accountDeltas.GetAllAssetResources()
 		for i := range assetResources {
-			writeAssetResource(round, &assetResources[i], batch)
+			writeAssetResource(round, &assetResources[i], closingAssetTxids, &assetAccounts, batch)
 		}
+		assetAccounts.queue(batch)
 	}
 
-	// Update `app` and `account_app` tables.
+	// Update `app` and `account_app` tables. Local state changes are
+	// coalesced into a single multi-row statement below instead of one
+	// UPSERT/DELETE per local state.
 	{
+		var appAccounts accountAppBatch
 		appResources := accountDeltas.GetAllAppResources()
 		for i := range appResources {
-			writeAppResource(round, &appResources[i], batch)
+			writeAppResource(round, &appResources[i], closingAppTxids, &appAccounts, batch)
+		}
+		appAccounts.queue(batch)
+	}
+}
+
+// closingTxidBytes returns the raw bytes to store in a closing_txid column
+// for id, or nil if id isn't known to have been destroyed by a top-level
+// transaction this round (it may still be live, or it may have been
+// destroyed by an inner transaction, which findDestroyingTxids doesn't
+// track).
+func closingTxidBytes(closingTxids map[uint64]string, id uint64) []byte {
+	txid, ok := closingTxids[id]
+	if !ok {
+		return nil
+	}
+	return []byte(txid)
+}
+
+// findDestroyingTxids scans payset for top-level transactions that destroy
+// an asset or application, returning the destroying transaction's id keyed
+// by the asset/app index it destroyed. It only looks at top-level
+// transactions: a resource destroyed by an inner transaction is still
+// recorded as deleted in the asset/app table, just without a closing_txid,
+// since attributing an inner transaction back to a top-level txid here
+// would duplicate the inner-transaction bookkeeping write_txn.go already
+// does when it imports the transaction itself.
+func findDestroyingTxids(payset []sdk.SignedTxnInBlock) (assetTxids map[uint64]string, appTxids map[uint64]string) {
+	assetTxids = make(map[uint64]string)
+	appTxids = make(map[uint64]string)
+
+	for i := range payset {
+		txn := &payset[i].SignedTxnWithAD.Txn
+		switch txn.Type {
+		case sdk.AssetConfigTx:
+			if txn.ConfigAsset != 0 && txn.AssetParams == (sdk.AssetParams{}) {
+				assetTxids[uint64(txn.ConfigAsset)] = crypto.TransactionIDString(*txn)
+			}
+		case sdk.ApplicationCallTx:
+			if txn.ApplicationID != 0 && txn.OnCompletion == sdk.DeleteApplicationOC {
+				appTxids[uint64(txn.ApplicationID)] = crypto.TransactionIDString(*txn)
+			}
 		}
 	}
 
+	return assetTxids, appTxids
 }
 
-func writeBoxMods(kvMods map[string]ledgercore.KvValueDelta, batch *pgx.Batch) error {
+func writeBoxMods(kvMods map[string]ledgercore.KvValueDelta, batch batchQueuer) error {
 	// INSERT INTO / UPDATE / DELETE FROM `app_box`
 	// WARNING: kvMods can in theory support more general storage types than app boxes.
 	// However, here we assume that all the provided kvMods represent app boxes.
@@ -359,19 +837,32 @@ func (w *Writer) AddBlock0(block *sdk.Block) error {
 // transactions and transaction participation. Those are imported by free functions in
 // the writer/ directory.
 func (w *Writer) AddBlock(block *sdk.Block, delta ledgercore.StateDelta) error {
-	var batch pgx.Batch
+	maxBatchStatements := w.maxBatchStatements
+	if maxBatchStatements == 0 {
+		maxBatchStatements = defaultMaxBatchStatements
+	}
+	// Statements are flushed to tx in chunks of at most maxBatchStatements
+	// as they're queued below, bounding the size of any individual batch
+	// sent for this round regardless of how many accounts/assets/apps it
+	// touches. They're still all queued and flushed inside the caller's
+	// serializable transaction, so the round becomes visible to other
+	// transactions atomically, exactly as it did before chunking.
+	batch := makeChunkedBatch(w.tx, maxBatchStatements)
 	addBlockHeader(&block.BlockHeader, &batch)
-	specialAddresses := types.SpecialAddresses{
-		FeeSink:     block.FeeSink,
-		RewardsPool: block.RewardsPool,
+	if !w.skipSpecialAccounts {
+		specialAddresses := types.SpecialAddresses{
+			FeeSink:     block.FeeSink,
+			RewardsPool: block.RewardsPool,
+		}
+		setSpecialAccounts(specialAddresses, &batch)
 	}
-	setSpecialAccounts(specialAddresses, &batch)
 	{
 		sigTypeDeltas, err := getSigTypeDeltas(block.Payset)
 		if err != nil {
 			return fmt.Errorf("AddBlock() err: %w", err)
 		}
-		writeAccountDeltas(block.Round, &delta.Accts, sigTypeDeltas, &batch)
+		closingAssetTxids, closingAppTxids := findDestroyingTxids(block.Payset)
+		writeAccountDeltas(block.Round, &delta.Accts, sigTypeDeltas, closingAssetTxids, closingAppTxids, w.enableBalanceHistory, w.enableRewardsHistory, &batch)
 	}
 	{
 		err := writeBoxMods(delta.KvMods, &batch)
@@ -379,20 +870,16 @@ func (w *Writer) AddBlock(block *sdk.Block, delta ledgercore.StateDelta) error {
 			return fmt.Errorf("AddBlock() err on boxes: %w", err)
 		}
 	}
+	updateAccountActivity(block.Round, block.Payset, &batch)
+	updateAccountHeartbeats(block.Round, block.Payset, &batch)
+	updateAppCallStats(block.Payset, &batch)
 	batch.Queue(updateAccountTotalsStmtName, encoding.EncodeAccountTotals(&delta.Totals))
-
-	results := w.tx.SendBatch(context.Background(), &batch)
-	// Clean the results off the connection's queue. Without this, weird things happen.
-	for i := 0; i < batch.Len(); i++ {
-		_, err := results.Exec()
-		if err != nil {
-			results.Close()
-			return fmt.Errorf("AddBlock() exec err: %w", err)
-		}
+	if w.enableOnlineStakeHistory {
+		batch.Queue(insertNetworkTotalsHistoryStmtName, uint64(block.Round), delta.Totals.Online.Money.Raw)
 	}
-	err := results.Close()
-	if err != nil {
-		return fmt.Errorf("AddBlock() close results err: %w", err)
+
+	if err := batch.Flush(); err != nil {
+		return fmt.Errorf("AddBlock() err: %w", err)
 	}
 
 	return nil
@@ -140,6 +140,47 @@ func TestWriterBlockHeaderTableBasic(t *testing.T) {
 	assert.Equal(t, block.BlockHeader, headerRead)
 }
 
+// TestWriterChunkedBatch forces AddBlock to flush its batch after every
+// single statement and checks the result is identical to an unchunked
+// write, i.e. that chunking doesn't change what ends up in the database.
+func TestWriterChunkedBatch(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block sdk.Block
+	block.BlockHeader.Round = sdk.Round(2)
+	block.BlockHeader.TimeStamp = 333
+	block.BlockHeader.RewardsLevel = 111111
+
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx)
+		require.NoError(t, err)
+		w.SetMaxBatchStatements(1)
+
+		err = w.AddBlock(&block, ledgercore.StateDelta{})
+		require.NoError(t, err)
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	row := db.QueryRow(context.Background(), "SELECT * FROM block_header")
+	var round uint64
+	var realtime time.Time
+	var rewardslevel uint64
+	var header []byte
+	err = row.Scan(&round, &realtime, &rewardslevel, &header)
+	require.NoError(t, err)
+
+	assert.Equal(t, block.BlockHeader.Round, sdk.Round(round))
+	assert.Equal(t, block.BlockHeader.RewardsLevel, rewardslevel)
+	headerRead, err := encoding.DecodeBlockHeader(header)
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockHeader, headerRead)
+}
+
 func TestWriterSpecialAccounts(t *testing.T) {
 	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
 	defer shutdownFunc()
@@ -0,0 +1,184 @@
+// You can build without postgres by `go build --tags nopostgres` but it's on by default
+//go:build !nopostgres
+// +build !nopostgres
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// maintenanceTables are the tables that receive the most write traffic during
+// import, and are therefore the ones most worth vacuuming proactively instead
+// of waiting on autovacuum.
+var maintenanceTables = []string{
+	"account",
+	"account_asset",
+	"account_app",
+	"asset",
+	"app",
+	"txn",
+	"txn_participation",
+}
+
+// TableBloat reports the estimated bloat for a single table, used to decide
+// whether a manual VACUUM or REINDEX is warranted.
+type TableBloat struct {
+	Table        string
+	TableBytes   int64
+	BloatBytes   int64
+	IndexBytes   int64
+	IndexName    string
+	IndexBloat   int64
+	EstimatedPct float64
+}
+
+// MaintenanceConfig controls the built-in maintenance scheduler.
+type MaintenanceConfig struct {
+	// AnalyzeAfterCatchup, when true, runs ANALYZE on the maintenance tables
+	// once the importer catches up to the chain tip.
+	AnalyzeAfterCatchup bool
+
+	// VacuumInterval is how often the scheduler wakes up to consider running
+	// a VACUUM pass. Zero disables the periodic vacuum pass entirely.
+	VacuumInterval time.Duration
+
+	// QuietHoursStart and QuietHoursEnd (in UTC, 0-23) bound the window during
+	// which scheduled vacuums are allowed to run. If both are zero, vacuums are
+	// allowed to run at any time.
+	QuietHoursStart int
+	QuietHoursEnd   int
+}
+
+// maintenance is the built-in subsystem that keeps hot tables analyzed and
+// vacuumed without requiring an operator to wire up a cron job.
+type maintenance struct {
+	db  *pgxpool.Pool
+	log *log.Logger
+	cfg MaintenanceConfig
+}
+
+func makeMaintenance(db *pgxpool.Pool, logger *log.Logger, cfg MaintenanceConfig) *maintenance {
+	return &maintenance{db: db, log: logger, cfg: cfg}
+}
+
+// analyzeNow runs ANALYZE on the maintenance tables. It is cheap relative to
+// VACUUM and safe to run synchronously right after a bulk catchup finishes.
+func (m *maintenance) analyzeNow(ctx context.Context) error {
+	for _, table := range maintenanceTables {
+		if _, err := m.db.Exec(ctx, "ANALYZE "+table); err != nil {
+			return fmt.Errorf("analyzeNow() table %s err: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// inQuietHours reports whether t falls inside the configured low-traffic
+// window. A zero-width window (start == end) means "always allowed".
+func (m *maintenance) inQuietHours(t time.Time) bool {
+	if m.cfg.QuietHoursStart == m.cfg.QuietHoursEnd {
+		return true
+	}
+	hour := t.UTC().Hour()
+	if m.cfg.QuietHoursStart < m.cfg.QuietHoursEnd {
+		return hour >= m.cfg.QuietHoursStart && hour < m.cfg.QuietHoursEnd
+	}
+	// window wraps midnight, e.g. 22 -> 4
+	return hour >= m.cfg.QuietHoursStart || hour < m.cfg.QuietHoursEnd
+}
+
+// vacuumHottest issues a plain VACUUM (not FULL, so it doesn't take an
+// exclusive lock) against the maintenance tables.
+func (m *maintenance) vacuumHottest(ctx context.Context) error {
+	for _, table := range maintenanceTables {
+		if _, err := m.db.Exec(ctx, "VACUUM "+table); err != nil {
+			return fmt.Errorf("vacuumHottest() table %s err: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// bloatReport queries pg_stat_user_tables for each maintenance table's size,
+// index size, and dead-tuple share as a rough estimate of bloat, for operators to inspect via logs or a
+// future metrics endpoint. This intentionally avoids the more expensive
+// pgstattuple extension, since it may not be installed.
+func (m *maintenance) bloatReport(ctx context.Context) ([]TableBloat, error) {
+	const query = `
+		SELECT
+			relname,
+			pg_table_size(relid) AS table_bytes,
+			pg_total_relation_size(relid) - pg_table_size(relid) AS index_bytes,
+			n_dead_tup,
+			n_live_tup
+		FROM pg_stat_user_tables
+		WHERE relname = ANY($1)`
+
+	rows, err := m.db.Query(ctx, query, maintenanceTables)
+	if err != nil {
+		return nil, fmt.Errorf("bloatReport() err: %w", err)
+	}
+	defer rows.Close()
+
+	var res []TableBloat
+	for rows.Next() {
+		var (
+			table                string
+			tableBytes, idxBytes int64
+			deadTup, liveTup     int64
+		)
+		if err := rows.Scan(&table, &tableBytes, &idxBytes, &deadTup, &liveTup); err != nil {
+			return nil, fmt.Errorf("bloatReport() scan err: %w", err)
+		}
+		pct := 0.0
+		if liveTup+deadTup > 0 {
+			pct = 100 * float64(deadTup) / float64(liveTup+deadTup)
+		}
+		res = append(res, TableBloat{
+			Table:        table,
+			TableBytes:   tableBytes,
+			IndexBytes:   idxBytes,
+			BloatBytes:   deadTup,
+			EstimatedPct: pct,
+		})
+	}
+	return res, rows.Err()
+}
+
+// start runs the periodic vacuum/bloat-report loop until ctx is canceled.
+// It is a no-op if VacuumInterval is zero.
+func (m *maintenance) start(ctx context.Context) {
+	if m.cfg.VacuumInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(m.cfg.VacuumInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !m.inQuietHours(now) {
+					continue
+				}
+				if report, err := m.bloatReport(ctx); err != nil {
+					m.log.WithError(err).Warn("maintenance: bloat report failed")
+				} else {
+					for _, tb := range report {
+						m.log.Infof("maintenance: table %s ~%.1f%% dead tuples", tb.Table, tb.EstimatedPct)
+					}
+				}
+				if err := m.vacuumHottest(ctx); err != nil {
+					m.log.WithError(err).Warn("maintenance: vacuum pass failed")
+				}
+			}
+		}
+	}()
+}
@@ -5,10 +5,13 @@
 package postgres
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"sync"
@@ -74,9 +77,16 @@ func OpenPostgres(connection string, opts idb.IndexerDbOptions, log *log.Logger)
 // Allow tests to inject a DB
 func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logger) (*IndexerDb, chan struct{}, error) {
 	idb := &IndexerDb{
-		readonly: opts.ReadOnly,
-		log:      logger,
-		db:       db,
+		readonly:                 opts.ReadOnly,
+		allowUnsupportedSchema:   opts.AllowUnsupportedSchema,
+		log:                      logger,
+		db:                       db,
+		dryRun:                   opts.DryRun,
+		enableAppStateHistory:    opts.EnableAppStateHistory,
+		enableBalanceHistory:     opts.EnableBalanceHistory,
+		enableRewardsHistory:     opts.EnableRewardsHistory,
+		enableOnlineStakeHistory: opts.EnableOnlineStakeHistory,
+		maxBatchStatements:       int(opts.MaxBatchStatements),
 	}
 
 	if idb.log == nil {
@@ -93,6 +103,9 @@ func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logge
 		if err != nil {
 			return nil, nil, fmt.Errorf("openPostgres() err: %w", err)
 		}
+		if err := checkSchemaCompatible(migrationState, opts.AllowUnsupportedSchema); err != nil {
+			return nil, nil, fmt.Errorf("openPostgres() err: %w", err)
+		}
 
 		ch = make(chan struct{})
 		if !migrationStateBlocked(migrationState) {
@@ -100,10 +113,21 @@ func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logge
 		}
 	} else {
 		var err error
+		idb.writerLockConn, err = acquireWriterLock(context.Background(), db)
+		if err != nil {
+			return nil, nil, fmt.Errorf("openPostgres() err: %w", err)
+		}
+
 		ch, err = idb.init(opts)
 		if err != nil {
 			return nil, nil, fmt.Errorf("initializing postgres: %v", err)
 		}
+
+		if opts.VacuumInterval > 0 {
+			var ctx context.Context
+			ctx, idb.maintenanceCancel = context.WithCancel(context.Background())
+			makeMaintenance(idb.db, idb.log, MaintenanceConfig{VacuumInterval: opts.VacuumInterval}).start(ctx)
+		}
 	}
 
 	return idb, ch, nil
@@ -111,28 +135,150 @@ func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logge
 
 // IndexerDb is an idb.IndexerDB implementation
 type IndexerDb struct {
-	readonly bool
-	log      *log.Logger
+	readonly               bool
+	allowUnsupportedSchema bool
+	log                    *log.Logger
 
 	db             *pgxpool.Pool
 	migration      *migration.Migration
 	accountingLock sync.Mutex
+
+	maintenanceCancel context.CancelFunc
+
+	// lastSpecialAddresses and lastConsensusVersion cache the previous
+	// round's fee sink / rewards pool and consensus version, guarded by
+	// accountingLock. AddBlock uses them to skip re-upserting the special
+	// accounts metastate row when nothing has changed since the last round.
+	lastSpecialAddresses *itypes.SpecialAddresses
+	lastConsensusVersion protocol.ConsensusVersion
+
+	// dryRun, when true, makes AddBlock run the real accounting SQL inside a
+	// transaction that is always rolled back, so the indexer can be soak
+	// tested against live traffic without mutating the database.
+	// dryRunNextRound tracks the round the importer believes it's on, since
+	// the real metastate never advances while dry-running.
+	dryRun          bool
+	dryRunNextRound *uint64
+
+	// enableAppStateHistory, when true, makes AddBlock also record every
+	// changed application global/local state key into app_state_history, so
+	// Applications/AppLocalState can reconstruct state as of a past round.
+	enableAppStateHistory bool
+
+	// enableBalanceHistory, when true, makes AddBlock also record an
+	// account_balance_history row for every account in the round's account
+	// deltas, so BalanceHistory can return a time series for an address.
+	enableBalanceHistory bool
+
+	// enableRewardsHistory, when true, makes AddBlock also record an
+	// account_reward_history row for every account in the round's account
+	// deltas, so AccountRewards can summarize rewards earned over a round
+	// range.
+	enableRewardsHistory bool
+
+	// enableOnlineStakeHistory, when true, makes AddBlock also record a
+	// network_totals_history row with the round's total online stake, so
+	// OnlineStakeHistory can return a time series of it.
+	enableOnlineStakeHistory bool
+
+	// maxBatchStatements bounds how many statements AddBlock queues into a
+	// single batch before flushing it to the database. Zero uses the
+	// writer package's own default.
+	maxBatchStatements int
+
+	// writerLockConn holds the session-level Postgres advisory lock that
+	// marks this process as the database's writer, for as long as the
+	// connection stays checked out of the pool. It's nil for read-only
+	// instances, which don't contend for writer status.
+	writerLockConn *pgxpool.Conn
+}
+
+// writerAdvisoryLockID identifies the session-level advisory lock taken by a
+// writer IndexerDb, so that a second indexer pointed at the same database
+// refuses to start rather than racing the first on imports.
+const writerAdvisoryLockID int64 = 643089217
+
+// acquireWriterLock takes the writer advisory lock on a dedicated connection
+// checked out of pool, so the lock is held until that connection is released
+// or closed. It returns an error if another writer already holds the lock.
+func acquireWriterLock(ctx context.Context, pool *pgxpool.Pool) (*pgxpool.Conn, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquireWriterLock() acquire err: %w", err)
+	}
+
+	var acquired bool
+	err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", writerAdvisoryLockID).Scan(&acquired)
+	if err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("acquireWriterLock() lock err: %w", err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, fmt.Errorf("another indexer writer already holds the database advisory lock")
+	}
+
+	return conn, nil
 }
 
 // Close is part of idb.IndexerDb.
 func (db *IndexerDb) Close() {
+	if db.maintenanceCancel != nil {
+		db.maintenanceCancel()
+	}
+	if db.writerLockConn != nil {
+		db.writerLockConn.Release()
+	}
 	db.db.Close()
 }
 
+// specialAccountsUnchanged reports whether block's fee sink, rewards pool,
+// and consensus version are identical to those of the previous round, and
+// records block's values for the next call. It must be called with
+// accountingLock held, and only for rounds after round 0 -- round 0 always
+// writes the special accounts metastate row unconditionally.
+func (db *IndexerDb) specialAccountsUnchanged(block *sdk.Block) bool {
+	current := itypes.SpecialAddresses{
+		FeeSink:     block.FeeSink,
+		RewardsPool: block.RewardsPool,
+	}
+
+	unchanged := db.lastSpecialAddresses != nil &&
+		*db.lastSpecialAddresses == current &&
+		db.lastConsensusVersion == protocol.ConsensusVersion(block.CurrentProtocol)
+
+	db.lastSpecialAddresses = &current
+	db.lastConsensusVersion = protocol.ConsensusVersion(block.CurrentProtocol)
+
+	return unchanged
+}
+
 // txWithRetry is a helper function that retries the function `f` in case the database
 // transaction in it fails due to a serialization error. `f` is provided
 // a transaction created using `opts`. If `f` experiences a database error, this error
 // must be included in `f`'s return error's chain, so that a serialization error can be
 // detected.
 func (db *IndexerDb) txWithRetry(opts pgx.TxOptions, f func(pgx.Tx) error) error {
+	if db.dryRun {
+		return db.txDryRun(opts, f)
+	}
 	return pgutil.TxWithRetry(db.db, opts, f, db.log)
 }
 
+// txDryRun runs `f` inside a transaction that is always rolled back, even on
+// success, so DryRun mode can exercise the real accounting SQL against the
+// real database without persisting anything. Unlike txWithRetry, it doesn't
+// retry on serialization errors since nothing it does is meant to stick.
+func (db *IndexerDb) txDryRun(opts pgx.TxOptions, f func(pgx.Tx) error) error {
+	tx, err := db.db.BeginTx(context.Background(), opts)
+	if err != nil {
+		return fmt.Errorf("txDryRun() begin tx err: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	return f(tx)
+}
+
 func (db *IndexerDb) isSetup() (bool, error) {
 	query := `SELECT 0 FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = 'metastate'`
 	row := db.db.QueryRow(context.Background(), query)
@@ -164,7 +310,7 @@ func (db *IndexerDb) init(opts idb.IndexerDbOptions) (chan struct{}, error) {
 		}
 
 		err = db.markMigrationsAsDone()
-		if err != nil {
+		if err != nil && !errors.Is(err, pgutil.ErrVersionConflict) {
 			return nil, fmt.Errorf("unable to confirm migration: %v", err)
 		}
 
@@ -192,20 +338,38 @@ func (db *IndexerDb) AddBlock(vblk *ledgercore.ValidatedBlock) error {
 	defer db.accountingLock.Unlock()
 
 	f := func(tx pgx.Tx) error {
-		// Check and increment next round counter.
-		importstate, err := db.getImportState(context.Background(), tx)
-		if err != nil {
-			return fmt.Errorf("AddBlock() err: %w", err)
+		// Check and increment next round counter. In DryRun mode the real
+		// metastate never advances (every transaction is rolled back), so the
+		// expected round is tracked in memory instead.
+		nextRoundToAccount := db.dryRunNextRound
+		if nextRoundToAccount == nil {
+			importstate, err := db.getImportState(context.Background(), tx)
+			if err != nil {
+				return fmt.Errorf("AddBlock() err: %w", err)
+			}
+			nextRoundToAccount = &importstate.NextRoundToAccount
 		}
-		if round != sdk.Round(importstate.NextRoundToAccount) {
+		if round < sdk.Round(*nextRoundToAccount) {
+			// This round was already accounted for. Rather than erroring out on
+			// the primary key violation this would otherwise cause, make AddBlock
+			// idempotent: retry logic and at-least-once import pipelines can
+			// re-submit a round they're not sure was durably recorded, and get a
+			// silent no-op back as long as it's the same block they already sent.
+			return checkReimportedBlock(context.Background(), tx, round, &block.BlockHeader)
+		}
+		if round != sdk.Round(*nextRoundToAccount) {
 			return fmt.Errorf(
 				"AddBlock() adding block round %d but next round to account is %d",
-				round, importstate.NextRoundToAccount)
+				round, *nextRoundToAccount)
 		}
-		importstate.NextRoundToAccount++
-		err = db.setImportState(tx, &importstate)
-		if err != nil {
-			return fmt.Errorf("AddBlock() err: %w", err)
+		if db.dryRun {
+			next := uint64(round) + 1
+			db.dryRunNextRound = &next
+		} else {
+			importstate := types.ImportState{NextRoundToAccount: *nextRoundToAccount + 1}
+			if err := db.setImportState(tx, &importstate); err != nil {
+				return fmt.Errorf("AddBlock() err: %w", err)
+			}
 		}
 
 		w, err := writer.MakeWriter(tx)
@@ -234,11 +398,29 @@ func (db *IndexerDb) AddBlock(vblk *ledgercore.ValidatedBlock) error {
 				if err != nil {
 					return err
 				}
-				return writer.AddTransactionParticipation(&block, tx)
+				if err := writer.AddTransactionParticipation(&block, tx); err != nil {
+					return err
+				}
+				if err := writer.AddMultisigParticipation(&block, tx); err != nil {
+					return err
+				}
+				if err := writer.AddParticipationUpdates(&block, tx); err != nil {
+					return err
+				}
+				if !db.enableAppStateHistory {
+					return nil
+				}
+				return writer.AddAppStateHistory(&block, tx)
 			}
 			err0 = db.txWithRetry(serializable, f)
 		}()
 
+		w.SetSkipSpecialAccounts(db.specialAccountsUnchanged(&block))
+		w.SetEnableBalanceHistory(db.enableBalanceHistory)
+		w.SetEnableRewardsHistory(db.enableRewardsHistory)
+		w.SetEnableOnlineStakeHistory(db.enableOnlineStakeHistory)
+		w.SetMaxBatchStatements(db.maxBatchStatements)
+
 		err = w.AddBlock(&block, vb.Delta)
 		if err != nil {
 			return fmt.Errorf("AddBlock() err: %w", err)
@@ -267,6 +449,40 @@ func (db *IndexerDb) AddBlock(vblk *ledgercore.ValidatedBlock) error {
 	return nil
 }
 
+// checkReimportedBlock compares a block whose round was already accounted
+// for against the header already committed for that round, so that
+// AddBlock can be idempotent: an identical header is a no-op, and a
+// different one means the round was reorged out from under the caller.
+func checkReimportedBlock(ctx context.Context, tx pgx.Tx, round sdk.Round, header *sdk.BlockHeader) error {
+	row := tx.QueryRow(ctx, `SELECT header FROM block_header WHERE round = $1`, uint64(round))
+	var headerjson []byte
+	err := row.Scan(&headerjson)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("checkReimportedBlock() round %d already accounted for but missing from block_header", round)
+	}
+	if err != nil {
+		return fmt.Errorf("checkReimportedBlock() err: %w", err)
+	}
+
+	if bytes.Equal(headerjson, encoding.EncodeBlockHeader(*header)) {
+		return nil
+	}
+	return fmt.Errorf("%w: round %d", idb.ErrorBlockReorg, round)
+}
+
+// genesisDeferredAccountIndexes lists the account table indexes LoadGenesis
+// drops before its bulk COPY and recreates afterward, instead of
+// maintaining them incrementally one genesis account at a time. They must
+// be kept in sync with the CREATE INDEX statements in setup_postgres.sql.
+var genesisDeferredAccountIndexes = []struct {
+	name            string
+	createStatement string
+}{
+	{"account_by_microalgos", "CREATE INDEX IF NOT EXISTS account_by_microalgos ON account ( microalgos, addr ) WHERE NOT deleted"},
+	{"account_by_created_at", "CREATE INDEX IF NOT EXISTS account_by_created_at ON account ( created_at, addr ) WHERE NOT deleted"},
+	{"account_by_last_active_round", "CREATE INDEX IF NOT EXISTS account_by_last_active_round ON account ( last_active_round, addr ) WHERE NOT deleted"},
+}
+
 // LoadGenesis is part of idb.IndexerDB
 func (db *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 	f := func(tx pgx.Tx) error {
@@ -287,13 +503,17 @@ func (db *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 				return fmt.Errorf("LoadGenesis() genesis hash not matching")
 			}
 		}
-		setAccountStatementName := "set_account"
-		query := `INSERT INTO account (addr, microalgos, rewardsbase, account_data, rewards_total, created_at, deleted) VALUES ($1, $2, 0, $3, $4, 0, false)`
-		_, err = tx.Prepare(context.Background(), setAccountStatementName, query)
-		if err != nil {
-			return fmt.Errorf("LoadGenesis() prepare tx err: %w", err)
+		// Genesis files for public networks hold hundreds of thousands of
+		// accounts; inserting them one at a time (and maintaining the
+		// account_by_* indexes as it goes) can take minutes. Drop those
+		// indexes and bulk-load with COPY instead, then rebuild them once
+		// at the end against the now-complete table.
+		for _, index := range genesisDeferredAccountIndexes {
+			_, err = tx.Exec(context.Background(), "DROP INDEX IF EXISTS "+index.name)
+			if err != nil {
+				return fmt.Errorf("LoadGenesis() drop index %s err: %w", index.name, err)
+			}
 		}
-		defer tx.Conn().Deallocate(context.Background(), setAccountStatementName)
 
 		proto, ok := config.Consensus[genesis.Proto]
 		if !ok {
@@ -302,6 +522,7 @@ func (db *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 		// TODO: remove accountTotals
 		var ot basics.OverflowTracker
 		var totals ledgercore.AccountTotals
+		rows := make([][]interface{}, len(genesis.Allocation))
 		for ai, alloc := range genesis.Allocation {
 			addr, err := sdk.DecodeAddress(alloc.Address)
 			if err != nil {
@@ -311,17 +532,31 @@ func (db *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 				return fmt.Errorf("LoadGenesis() genesis account[%d] has unhandled asset", ai)
 			}
 			accountData := ledgercore.ToAccountData(alloc.State)
-			_, err = tx.Exec(
-				context.Background(), setAccountStatementName,
-				addr[:], alloc.State.MicroAlgos.Raw,
-				encoding.EncodeTrimmedLcAccountData(encoding.TrimLcAccountData(accountData)), 0)
-			if err != nil {
-				return fmt.Errorf("LoadGenesis() error setting genesis account[%d], %w", ai, err)
+			rows[ai] = []interface{}{
+				addr[:], alloc.State.MicroAlgos.Raw, int64(0),
+				encoding.EncodeTrimmedLcAccountData(encoding.TrimLcAccountData(accountData)),
+				int64(0), int64(0), false,
 			}
 
 			totals.AddAccount(proto, accountData, &ot)
 		}
 
+		_, err = tx.CopyFrom(
+			context.Background(),
+			pgx.Identifier{"account"},
+			[]string{"addr", "microalgos", "rewardsbase", "account_data", "rewards_total", "created_at", "deleted"},
+			pgx.CopyFromRows(rows))
+		if err != nil {
+			return fmt.Errorf("LoadGenesis() copy from err: %w", err)
+		}
+
+		for _, index := range genesisDeferredAccountIndexes {
+			_, err = tx.Exec(context.Background(), index.createStatement)
+			if err != nil {
+				return fmt.Errorf("LoadGenesis() create index %s err: %w", index.name, err)
+			}
+		}
+
 		err = db.setMetastate(
 			tx, schema.AccountTotals, string(encoding.EncodeAccountTotals(&totals)))
 		if err != nil {
@@ -357,6 +592,13 @@ func (db *IndexerDb) setMetastate(tx pgx.Tx, key, jsonStrValue string) (err erro
 	return pgutil.SetMetastate(db.db, tx, key, jsonStrValue)
 }
 
+// setMetastateCAS is like setMetastate, but only writes if key is still at
+// expectedVersion, returning pgutil.ErrVersionConflict otherwise. If `tx` is
+// nil, use a normal query.
+func (db *IndexerDb) setMetastateCAS(tx pgx.Tx, key, jsonStrValue string, expectedVersion int64) error {
+	return pgutil.SetMetastateCAS(db.db, tx, key, jsonStrValue, expectedVersion)
+}
+
 // Returns idb.ErrorNotInitialized if uninitialized.
 // If `tx` is nil, use a normal query.
 func (db *IndexerDb) getImportState(ctx context.Context, tx pgx.Tx) (types.ImportState, error) {
@@ -426,6 +668,9 @@ func (db *IndexerDb) getNextRoundToAccount(ctx context.Context, tx pgx.Tx) (uint
 // GetNextRoundToAccount is part of idb.IndexerDB
 // Returns ErrorNotInitialized if genesis is not loaded.
 func (db *IndexerDb) GetNextRoundToAccount() (uint64, error) {
+	if db.dryRun && db.dryRunNextRound != nil {
+		return *db.dryRunNextRound, nil
+	}
 	return db.getNextRoundToAccount(context.Background(), nil)
 }
 
@@ -443,6 +688,30 @@ func (db *IndexerDb) getMaxRoundAccounted(ctx context.Context, tx pgx.Tx) (uint6
 	return round, nil
 }
 
+// explainRowEstimate runs EXPLAIN (FORMAT JSON) on query and returns the
+// planner's estimated row count for the resulting plan, for callers that
+// want an approximate count without paying for the query itself.
+func (db *IndexerDb) explainRowEstimate(ctx context.Context, query string, whereArgs []interface{}) (uint64, error) {
+	var plan []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+
+	row := db.db.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, whereArgs...)
+	var planJSON []byte
+	if err := row.Scan(&planJSON); err != nil {
+		return 0, fmt.Errorf("explain err %v", err)
+	}
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return 0, fmt.Errorf("explain decode err %v", err)
+	}
+	if len(plan) == 0 {
+		return 0, nil
+	}
+	return uint64(plan[0].Plan.PlanRows), nil
+}
+
 // GetBlock is part of idb.IndexerDB
 func (db *IndexerDb) GetBlock(ctx context.Context, round uint64, options idb.GetBlockOptions) (blockHeader sdk.BlockHeader, transactions []idb.TxnRow, err error) {
 	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
@@ -501,6 +770,90 @@ func (db *IndexerDb) GetBlock(ctx context.Context, round uint64, options idb.Get
 	return blockHeader, transactions, nil
 }
 
+// GetBlockHeaders is part of idb.IndexerDB
+func (db *IndexerDb) GetBlockHeaders(ctx context.Context, filter idb.BlockHeadersQuery) (<-chan idb.BlockHeaderRow, uint64) {
+	out := make(chan idb.BlockHeaderRow, 1)
+
+	query := "SELECT round, header FROM block_header"
+	var whereArgs []interface{}
+	whereParts := make([]string, 0, 2)
+	if filter.MinRound != 0 {
+		whereArgs = append(whereArgs, filter.MinRound)
+		whereParts = append(whereParts, fmt.Sprintf("round >= $%d", len(whereArgs)))
+	}
+	if filter.MaxRound != 0 {
+		whereArgs = append(whereArgs, filter.MaxRound)
+		whereParts = append(whereParts, fmt.Sprintf("round <= $%d", len(whereArgs)))
+	}
+	if len(whereParts) > 0 {
+		query += " WHERE " + strings.Join(whereParts, " AND ")
+	}
+	query += " ORDER BY round ASC"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.BlockHeaderRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.BlockHeaderRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.BlockHeaderRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldBlockHeadersThread(rows, out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+
+	return out, round
+}
+
+func (db *IndexerDb) yieldBlockHeadersThread(rows pgx.Rows, out chan idb.BlockHeaderRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var round uint64
+		var headerjson []byte
+		err := rows.Scan(&round, &headerjson)
+		if err != nil {
+			out <- idb.BlockHeaderRow{Error: err}
+			break
+		}
+		header, err := encoding.DecodeBlockHeader(headerjson)
+		if err != nil {
+			out <- idb.BlockHeaderRow{Error: err}
+			break
+		}
+		out <- idb.BlockHeaderRow{Round: round, Header: header}
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.BlockHeaderRow{Error: err}
+	}
+}
+
 func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []interface{}, err error) {
 	// TODO? There are some combinations of tf params that will
 	// yield no results and we could catch that before asking the
@@ -509,6 +862,7 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 	whereParts := make([]string, 0, maxWhereParts)
 	whereArgs = make([]interface{}, 0, maxWhereParts)
 	joinParticipation := false
+	joinMultisig := false
 	partNumber := 1
 	if tf.Address != nil {
 		whereParts = append(whereParts, fmt.Sprintf("p.addr = $%d", partNumber))
@@ -615,6 +969,11 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		whereArgs = append(whereArgs, tf.Txid)
 		partNumber++
 	}
+	if len(tf.GroupID) != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("t.txn -> 'txn' ->> 'grp' = $%d", partNumber))
+		whereArgs = append(whereArgs, encoding.Base64(tf.GroupID))
+		partNumber++
+	}
 	if tf.Round != nil {
 		whereParts = append(whereParts, fmt.Sprintf("t.round = $%d", partNumber))
 		whereArgs = append(whereArgs, *tf.Round)
@@ -640,10 +999,39 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		whereArgs = append(whereArgs, tf.SigType)
 		partNumber++
 	}
+	if len(tf.LogicSigHash) > 0 {
+		whereParts = append(whereParts, fmt.Sprintf("t.lsig_hash = $%d", partNumber))
+		whereArgs = append(whereArgs, tf.LogicSigHash)
+		partNumber++
+	}
+	if tf.MultisigSubsigner != nil {
+		whereParts = append(whereParts, fmt.Sprintf("m.addr = $%d", partNumber))
+		whereArgs = append(whereArgs, tf.MultisigSubsigner)
+		partNumber++
+		joinMultisig = true
+	}
 	if len(tf.NotePrefix) > 0 {
-		whereParts = append(whereParts, fmt.Sprintf("substring(decode(t.txn -> 'txn' ->> 'note', 'base64') from 1 for %d) = $%d", len(tf.NotePrefix), partNumber))
-		whereArgs = append(whereArgs, tf.NotePrefix)
+		// Match against the indexed note_prefix column first, so this can use
+		// an index scan instead of decoding every row's note. note_prefix
+		// only stores the first idb.NotePrefixLength bytes, so a longer
+		// filter needs an additional check against the full decoded note.
+		indexedPrefix := tf.NotePrefix
+		if len(indexedPrefix) > idb.NotePrefixLength {
+			indexedPrefix = indexedPrefix[:idb.NotePrefixLength]
+		}
+		whereParts = append(whereParts, fmt.Sprintf("t.note_prefix >= $%d", partNumber))
+		whereArgs = append(whereArgs, indexedPrefix)
 		partNumber++
+		if upper := notePrefixUpperBound(indexedPrefix); upper != nil {
+			whereParts = append(whereParts, fmt.Sprintf("t.note_prefix < $%d", partNumber))
+			whereArgs = append(whereArgs, upper)
+			partNumber++
+		}
+		if len(tf.NotePrefix) > idb.NotePrefixLength {
+			whereParts = append(whereParts, fmt.Sprintf("substring(decode(t.txn -> 'txn' ->> 'note', 'base64') from 1 for %d) = $%d", len(tf.NotePrefix), partNumber))
+			whereArgs = append(whereArgs, tf.NotePrefix)
+			partNumber++
+		}
 	}
 	if tf.AlgosGT != nil {
 		whereParts = append(whereParts, fmt.Sprintf("(t.txn -> 'txn' -> 'amt')::bigint > $%d", partNumber))
@@ -665,9 +1053,28 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		whereArgs = append(whereArgs, *tf.EffectiveAmountLT)
 		partNumber++
 	}
+	if tf.MinFee != nil {
+		whereParts = append(whereParts, fmt.Sprintf("t.fee >= $%d", partNumber))
+		whereArgs = append(whereArgs, *tf.MinFee)
+		partNumber++
+	}
+	if tf.MaxFee != nil {
+		whereParts = append(whereParts, fmt.Sprintf("t.fee <= $%d", partNumber))
+		whereArgs = append(whereArgs, *tf.MaxFee)
+		partNumber++
+	}
 	if tf.RekeyTo != nil && (*tf.RekeyTo) {
 		whereParts = append(whereParts, "(t.txn -> 'txn' -> 'rekey') IS NOT NULL")
 	}
+	if tf.ClosedAccount != nil && (*tf.ClosedAccount) {
+		whereParts = append(whereParts, "(t.txn -> 'ca') IS NOT NULL")
+	}
+	if tf.ClosedAssetHolding != nil && (*tf.ClosedAssetHolding) {
+		whereParts = append(whereParts, "(t.extra ->> 'aca') IS NOT NULL")
+	}
+	if tf.AssetSenderSet != nil && (*tf.AssetSenderSet) {
+		whereParts = append(whereParts, "(t.txn -> 'txn' -> 'asnd') IS NOT NULL")
+	}
 
 	// If returnInnerTxnOnly flag is false, then return the root transaction
 	if !tf.ReturnInnerTxnOnly {
@@ -679,6 +1086,9 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 	if joinParticipation {
 		query += " JOIN txn_participation p ON t.round = p.round AND t.intra = p.intra"
 	}
+	if joinMultisig {
+		query += " JOIN txn_multisig_participation m ON t.round = m.round AND t.intra = m.intra"
+	}
 
 	// join in the root transaction if the returnInnerTxnOnly flag is false
 	if !tf.ReturnInnerTxnOnly {
@@ -702,6 +1112,22 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 	return
 }
 
+// notePrefixUpperBound returns the smallest byte string greater than every
+// string with the given prefix, for use as an exclusive upper bound in a
+// note_prefix range scan. It returns nil if prefix is empty or consists
+// entirely of 0xff bytes, in which case there is no such upper bound and the
+// caller should omit it.
+func notePrefixUpperBound(prefix []byte) []byte {
+	upper := append([]byte(nil), prefix...)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] != 0xff {
+			upper[i]++
+			return upper[:i+1]
+		}
+	}
+	return nil
+}
+
 // This function blocks. `tx` must be non-nil.
 func (db *IndexerDb) yieldTxns(ctx context.Context, tx pgx.Tx, tf idb.TransactionFilter, out chan<- idb.TxnRow) {
 	if len(tf.NextToken) > 0 {
@@ -725,6 +1151,22 @@ func (db *IndexerDb) yieldTxns(ctx context.Context, tx pgx.Tx, tf idb.Transactio
 	db.yieldTxnsThreadSimple(rows, out, nil, nil)
 }
 
+// EstimateTransactionsCount is part of idb.IndexerDB. It asks the query
+// planner how many rows tf's query would touch, instead of actually running
+// it, trading exactness for speed.
+func (db *IndexerDb) EstimateTransactionsCount(ctx context.Context, tf idb.TransactionFilter) (uint64, error) {
+	// The planner reports a Limit node's Plan Rows as min(limit, child
+	// estimate), so explaining the paginated query would just give back the
+	// page size instead of an estimate of the total matches. Explain the
+	// unlimited query instead.
+	tf.Limit = 0
+	query, whereArgs, err := buildTransactionQuery(tf)
+	if err != nil {
+		return 0, fmt.Errorf("txn query err %v", err)
+	}
+	return db.explainRowEstimate(ctx, query, whereArgs)
+}
+
 // Transactions is part of idb.IndexerDB
 func (db *IndexerDb) Transactions(ctx context.Context, tf idb.TransactionFilter) (<-chan idb.TxnRow, uint64) {
 	out := make(chan idb.TxnRow, 1)
@@ -920,6 +1362,11 @@ var statusStrings = []string{"Offline", "Online", "NotParticipating"}
 
 const offlineStatusIdx = 0
 
+// onlineStatusIdx is statusStrings' index for basics.Online, the
+// ledgercore.AccountData.Status value recorded under the account_data JSONB
+// "onl" key, usable directly in a SQL predicate against that column.
+const onlineStatusIdx = 1
+
 func tealValueToModel(tv basics.TealValue) models.TealValue {
 	switch tv.Type {
 	case basics.TealUintType:
@@ -950,6 +1397,55 @@ func tealKeyValueToModel(tkv basics.TealKeyValue) *models.TealKeyValueStore {
 	return &out
 }
 
+// historicalAppState reconstructs an application's global (addr == nil) or
+// local (addr set) state as of asOfRound from app_state_history, for
+// ApplicationQuery.Round. It's only accurate for rounds recorded while
+// IndexerDbOptions.EnableAppStateHistory was turned on.
+func (db *IndexerDb) historicalAppState(ctx context.Context, tx pgx.Tx, appID uint64, addr []byte, asOfRound uint64) (*models.TealKeyValueStore, error) {
+	query := `SELECT DISTINCT ON (key) key, action, value_bytes, value_uint
+		FROM app_state_history
+		WHERE app = $1 AND round <= $2 AND addr `
+	args := []interface{}{appID, asOfRound}
+	if addr == nil {
+		query += "IS NULL"
+	} else {
+		query += "= $3"
+		args = append(args, addr)
+	}
+	query += " ORDER BY key, round DESC, intra DESC"
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tkv models.TealKeyValueStore
+	for rows.Next() {
+		var key []byte
+		var action int16
+		var valueBytes []byte
+		var valueUint *uint64
+		if err := rows.Scan(&key, &action, &valueBytes, &valueUint); err != nil {
+			return nil, err
+		}
+		if sdk.DeltaAction(action) == sdk.DeleteAction {
+			continue
+		}
+		tv := models.TealValue{Type: uint64(action)}
+		if sdk.DeltaAction(action) == sdk.SetBytesAction {
+			tv.Bytes = encoding.Base64(valueBytes)
+		} else if valueUint != nil {
+			tv.Uint = *valueUint
+		}
+		tkv = append(tkv, models.TealKeyValue{Key: encoding.Base64(key), Value: tv})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &tkv, nil
+}
+
 func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 	count := uint64(0)
 	defer func() {
@@ -971,6 +1467,9 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		var rewardsbase uint64
 		var keytype *string
 		var accountDataJSONStr []byte
+		var totalTransactions uint64
+		var lastActiveRound uint64
+		var lastHeartbeatRound uint64
 
 		// below are bytes of json serialization
 
@@ -1004,7 +1503,7 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		var localStateDeletedBytes []byte
 
 		// build list of columns to scan using include options like buildAccountQuery
-		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
+		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr, &totalTransactions, &lastActiveRound, &lastHeartbeatRound}
 		if req.opts.IncludeAssetHoldings {
 			cols = append(cols, &holdingAssetids, &holdingAmount, &holdingFrozen, &holdingCreatedBytes, &holdingClosedBytes, &holdingDeletedBytes)
 		}
@@ -1037,6 +1536,13 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		account.Deleted = nullableBoolPtr(deleted)
 		account.RewardBase = new(uint64)
 		*account.RewardBase = rewardsbase
+		account.TotalTransactions = uint64Ptr(totalTransactions)
+		if lastActiveRound != 0 {
+			account.LastActiveRound = uint64Ptr(lastActiveRound)
+		}
+		if lastHeartbeatRound != 0 {
+			account.LastHeartbeatRound = uint64Ptr(lastHeartbeatRound)
+		}
 		// default to Offline in there have been no keyreg transactions.
 		account.Status = statusStrings[offlineStatusIdx]
 		if keytype != nil && *keytype != "" {
@@ -1533,6 +2039,18 @@ type getAccountsRequest struct {
 	start       time.Time
 }
 
+// EstimateAccountsCount is part of idb.IndexerDB. It is the accounts
+// analogue of EstimateTransactionsCount.
+func (db *IndexerDb) EstimateAccountsCount(ctx context.Context, opts idb.AccountQueryOptions) (uint64, error) {
+	// The planner reports a Limit node's Plan Rows as min(limit, child
+	// estimate), so explaining the paginated query would just give back the
+	// page size instead of an estimate of the total matches. Explain the
+	// unlimited query instead.
+	opts.Limit = 0
+	query, whereArgs := db.buildAccountQuery(opts, false)
+	return db.explainRowEstimate(ctx, query, whereArgs)
+}
+
 // GetAccounts is part of idb.IndexerDB
 func (db *IndexerDb) GetAccounts(ctx context.Context, opts idb.AccountQueryOptions) (<-chan idb.AccountRow, uint64) {
 	out := make(chan idb.AccountRow, 1)
@@ -1673,8 +2191,11 @@ func (db *IndexerDb) checkAccountResourceLimit(ctx context.Context, tx pgx.Tx, o
 		var rewardsbase uint64
 		var keytype *string
 		var accountDataJSONStr []byte
+		var totalTransactions uint64
+		var lastActiveRound uint64
+		var lastHeartbeatRound uint64
 		var holdingCount, assetCount, appCount, lsCount sql.NullInt64
-		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
+		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr, &totalTransactions, &lastActiveRound, &lastHeartbeatRound}
 		if countOnly {
 			if o.IncludeAssetHoldings {
 				cols = append(cols, &holdingCount)
@@ -1740,9 +2261,24 @@ func (db *IndexerDb) checkAccountResourceLimit(ctx context.Context, tx pgx.Tx, o
 	return nil
 }
 
+// accountOrderByExpr returns the SQL expression to sort/page accounts by for
+// orderBy, or "" for the original address-only ordering.
+func accountOrderByExpr(orderBy idb.AccountOrderBy) string {
+	switch orderBy {
+	case idb.AccountOrderByBalance:
+		return "a.microalgos"
+	case idb.AccountOrderByCreatedAt:
+		return "a.created_at"
+	case idb.AccountOrderByLastActive:
+		return "a.last_active_round"
+	default:
+		return ""
+	}
+}
+
 func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly bool) (query string, whereArgs []interface{}) {
 	// Construct query for fetching accounts...
-	const maxWhereParts = 9
+	const maxWhereParts = 10
 	whereParts := make([]string, 0, maxWhereParts)
 	whereArgs = make([]interface{}, 0, maxWhereParts)
 	partNumber := 1
@@ -1771,7 +2307,12 @@ func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly b
 		partNumber++
 	}
 	// filters against main account table
-	if len(opts.GreaterThanAddress) > 0 {
+	orderExpr := accountOrderByExpr(opts.OrderBy)
+	// When ordering by something other than address, the composite tiebreak
+	// clause below already subsumes this address-only cursor; applying both
+	// would AND them together and drop every next-page row whose order value
+	// is past the cursor but whose address happens to sort below it.
+	if len(opts.GreaterThanAddress) > 0 && orderExpr == "" {
 		whereParts = append(whereParts, fmt.Sprintf("a.addr > $%d", partNumber))
 		whereArgs = append(whereArgs, opts.GreaterThanAddress)
 		partNumber++
@@ -1799,7 +2340,24 @@ func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly b
 		whereArgs = append(whereArgs, encoding.Base64(opts.EqualToAuthAddr))
 		partNumber++
 	}
-	query = `SELECT a.addr, a.microalgos, a.rewards_total, a.created_at, a.closed_at, a.deleted, a.rewardsbase, a.keytype, a.account_data FROM account a`
+	if opts.OnlineOnly {
+		whereParts = append(whereParts, fmt.Sprintf("(a.account_data ->> 'onl')::int = %d", onlineStatusIdx))
+	}
+	if opts.MaxLastHeartbeatRound != nil {
+		whereParts = append(whereParts, fmt.Sprintf("a.last_heartbeat_round <= $%d", partNumber))
+		whereArgs = append(whereArgs, *opts.MaxLastHeartbeatRound)
+		partNumber++
+	}
+	if orderExpr != "" && opts.GreaterThanOrderValue != nil && len(opts.GreaterThanAddress) > 0 {
+		cmp := ">"
+		if opts.OrderDescending {
+			cmp = "<"
+		}
+		whereParts = append(whereParts, fmt.Sprintf("(%s %s $%d OR (%s = $%d AND a.addr > $%d))", orderExpr, cmp, partNumber, orderExpr, partNumber, partNumber+1))
+		whereArgs = append(whereArgs, *opts.GreaterThanOrderValue, opts.GreaterThanAddress)
+		partNumber += 2
+	}
+	query = `SELECT a.addr, a.microalgos, a.rewards_total, a.created_at, a.closed_at, a.deleted, a.rewardsbase, a.keytype, a.account_data, a.total_transactions, a.last_active_round, a.last_heartbeat_round FROM account a`
 	if opts.HasAssetID != 0 {
 		// inner join requires match, filtering on presence of asset
 		query += " JOIN qasf ON a.addr = qasf.addr"
@@ -1812,7 +2370,17 @@ func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly b
 		whereStr := strings.Join(whereParts, " AND ")
 		query += " WHERE " + whereStr
 	}
-	query += " ORDER BY a.addr ASC"
+	if orderExpr == "" {
+		query += " ORDER BY a.addr ASC"
+	} else {
+		dir := "ASC"
+		if opts.OrderDescending {
+			dir = "DESC"
+		}
+		// a.addr ASC breaks ties between accounts sharing an OrderBy value,
+		// matching the tiebreaker the WHERE clause above pages through.
+		query += fmt.Sprintf(" ORDER BY %s %s, a.addr ASC", orderExpr, dir)
+	}
 	if opts.Limit != 0 {
 		query += fmt.Sprintf(" LIMIT %d", opts.Limit)
 	}
@@ -1871,7 +2439,7 @@ func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly b
 	}
 
 	// query results
-	query += ` SELECT za.addr, za.microalgos, za.rewards_total, za.created_at, za.closed_at, za.deleted, za.rewardsbase, za.keytype, za.account_data`
+	query += ` SELECT za.addr, za.microalgos, za.rewards_total, za.created_at, za.closed_at, za.deleted, za.rewardsbase, za.keytype, za.account_data, za.total_transactions, za.last_active_round, za.last_heartbeat_round`
 	if opts.IncludeAssetHoldings {
 		if countOnly {
 			query += `, qaa.holding_count`
@@ -1921,8 +2489,8 @@ func (db *IndexerDb) buildAccountQuery(opts idb.AccountQueryOptions, countOnly b
 
 // Assets is part of idb.IndexerDB
 func (db *IndexerDb) Assets(ctx context.Context, filter idb.AssetsQuery) (<-chan idb.AssetRow, uint64) {
-	query := `SELECT index, creator_addr, params, created_at, closed_at, deleted FROM asset a`
-	const maxWhereParts = 14
+	query := `SELECT index, creator_addr, params, created_at, closed_at, deleted, num_holders, closing_txid FROM asset a`
+	const maxWhereParts = 18
 	whereParts := make([]string, 0, maxWhereParts)
 	whereArgs := make([]interface{}, 0, maxWhereParts)
 	partNumber := 1
@@ -1942,24 +2510,51 @@ func (db *IndexerDb) Assets(ctx context.Context, filter idb.AssetsQuery) (<-chan
 		partNumber++
 	}
 	if filter.Name != "" {
-		whereParts = append(whereParts, fmt.Sprintf("a.params ->> 'an' ILIKE $%d", partNumber))
+		whereParts = append(whereParts, fmt.Sprintf("COALESCE(NULLIF(a.params ->> 'an', ''), a.params ->> 'ans') ILIKE $%d", partNumber))
 		whereArgs = append(whereArgs, "%"+filter.Name+"%")
 		partNumber++
 	}
 	if filter.Unit != "" {
-		whereParts = append(whereParts, fmt.Sprintf("a.params ->> 'un' ILIKE $%d", partNumber))
+		whereParts = append(whereParts, fmt.Sprintf("COALESCE(NULLIF(a.params ->> 'un', ''), a.params ->> 'uns') ILIKE $%d", partNumber))
 		whereArgs = append(whereArgs, "%"+filter.Unit+"%")
 		partNumber++
 	}
 	if filter.Query != "" {
 		qs := "%" + filter.Query + "%"
-		whereParts = append(whereParts, fmt.Sprintf("(a.params ->> 'un' ILIKE $%d OR a.params ->> 'an' ILIKE $%d)", partNumber, partNumber))
+		whereParts = append(whereParts, fmt.Sprintf(
+			"(COALESCE(NULLIF(a.params ->> 'un', ''), a.params ->> 'uns') ILIKE $%d OR COALESCE(NULLIF(a.params ->> 'an', ''), a.params ->> 'ans') ILIKE $%d)",
+			partNumber, partNumber))
 		whereArgs = append(whereArgs, qs)
 		partNumber++
 	}
-	if !filter.IncludeDeleted {
+	if !filter.IncludeDeleted && filter.DestroyedAfterRound == 0 && filter.DestroyedBeforeRound == 0 {
 		whereParts = append(whereParts, "NOT a.deleted")
 	}
+	if filter.MinHolders != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("a.num_holders >= $%d", partNumber))
+		whereArgs = append(whereArgs, filter.MinHolders)
+		partNumber++
+	}
+	if filter.CreatedAfterRound != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("a.created_at > $%d", partNumber))
+		whereArgs = append(whereArgs, filter.CreatedAfterRound)
+		partNumber++
+	}
+	if filter.CreatedBeforeRound != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("a.created_at < $%d", partNumber))
+		whereArgs = append(whereArgs, filter.CreatedBeforeRound)
+		partNumber++
+	}
+	if filter.DestroyedAfterRound != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("a.closed_at > $%d", partNumber))
+		whereArgs = append(whereArgs, filter.DestroyedAfterRound)
+		partNumber++
+	}
+	if filter.DestroyedBeforeRound != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("a.closed_at < $%d", partNumber))
+		whereArgs = append(whereArgs, filter.DestroyedBeforeRound)
+		partNumber++
+	}
 	if len(whereParts) > 0 {
 		whereStr := strings.Join(whereParts, " AND ")
 		query += " WHERE " + whereStr
@@ -2022,9 +2617,11 @@ func (db *IndexerDb) yieldAssetsThread(filter idb.AssetsQuery, rows pgx.Rows, ou
 		var created *uint64
 		var closed *uint64
 		var deleted *bool
+		var numHolders uint64
+		var closingTxid []byte
 		var err error
 
-		err = rows.Scan(&index, &creatorAddr, &paramsJSONStr, &created, &closed, &deleted)
+		err = rows.Scan(&index, &creatorAddr, &paramsJSONStr, &created, &closed, &deleted, &numHolders, &closingTxid)
 		if err != nil {
 			out <- idb.AssetRow{Error: err}
 			break
@@ -2041,6 +2638,8 @@ func (db *IndexerDb) yieldAssetsThread(filter idb.AssetsQuery, rows pgx.Rows, ou
 			CreatedRound: created,
 			ClosedRound:  closed,
 			Deleted:      deleted,
+			NumHolders:   numHolders,
+			ClosingTxid:  closingTxid,
 		}
 		out <- rec
 	}
@@ -2176,7 +2775,8 @@ func (db *IndexerDb) yieldAssetBalanceThread(rows pgx.Rows, out chan<- idb.Asset
 func (db *IndexerDb) Applications(ctx context.Context, filter idb.ApplicationQuery) (<-chan idb.ApplicationRow, uint64) {
 	out := make(chan idb.ApplicationRow, 1)
 
-	query := `SELECT index, creator, params, created_at, closed_at, deleted FROM app `
+	query := `SELECT index, creator, params, created_at, closed_at, deleted, acs.call_count, acs.unique_callers, app.closing_txid
+		FROM app LEFT JOIN app_call_stats acs ON acs.app = app.index `
 
 	const maxWhereParts = 4
 	whereParts := make([]string, 0, maxWhereParts)
@@ -2237,7 +2837,7 @@ func (db *IndexerDb) Applications(ctx context.Context, filter idb.ApplicationQue
 	}
 
 	go func() {
-		db.yieldApplicationsThread(rows, out)
+		db.yieldApplicationsThread(ctx, tx, filter.Round, rows, out)
 		// Because we return a channel into a "callWithTimeout" function,
 		// We need to make sure that rollback is called before close()
 		// otherwise we can end up with a situation where "callWithTimeout"
@@ -2250,7 +2850,7 @@ func (db *IndexerDb) Applications(ctx context.Context, filter idb.ApplicationQue
 	return out, round
 }
 
-func (db *IndexerDb) yieldApplicationsThread(rows pgx.Rows, out chan idb.ApplicationRow) {
+func (db *IndexerDb) yieldApplicationsThread(ctx context.Context, tx pgx.Tx, asOfRound *uint64, rows pgx.Rows, out chan idb.ApplicationRow) {
 	defer rows.Close()
 
 	for rows.Next() {
@@ -2260,7 +2860,10 @@ func (db *IndexerDb) yieldApplicationsThread(rows pgx.Rows, out chan idb.Applica
 		var created *uint64
 		var closed *uint64
 		var deleted *bool
-		err := rows.Scan(&index, &creator, &paramsjson, &created, &closed, &deleted)
+		var callCount sql.NullInt64
+		var uniqueCallers sql.NullInt64
+		var closingTxid []byte
+		err := rows.Scan(&index, &creator, &paramsjson, &created, &closed, &deleted, &callCount, &uniqueCallers, &closingTxid)
 		if err != nil {
 			out <- idb.ApplicationRow{Error: err}
 			break
@@ -2270,6 +2873,15 @@ func (db *IndexerDb) yieldApplicationsThread(rows pgx.Rows, out chan idb.Applica
 		rec.Application.CreatedAtRound = created
 		rec.Application.DeletedAtRound = closed
 		rec.Application.Deleted = deleted
+		if callCount.Valid {
+			rec.Application.CallCount = uint64Ptr(uint64(callCount.Int64))
+		}
+		if uniqueCallers.Valid {
+			rec.Application.UniqueCallers = uint64Ptr(uint64(uniqueCallers.Int64))
+		}
+		if len(closingTxid) > 0 {
+			rec.Application.ClosingTransaction = stringPtr(string(closingTxid))
+		}
 		ap, err := encoding.DecodeAppParams(paramsjson)
 		if err != nil {
 			rec.Error = fmt.Errorf("app=%d json err: %w", index, err)
@@ -2299,6 +2911,16 @@ func (db *IndexerDb) yieldApplicationsThread(rows pgx.Rows, out chan idb.Applica
 			*rec.Application.Params.ExtraProgramPages = uint64(ap.ExtraProgramPages)
 		}
 
+		if asOfRound != nil {
+			globalState, err := db.historicalAppState(ctx, tx, index, nil, *asOfRound)
+			if err != nil {
+				rec.Error = fmt.Errorf("app=%d history err: %w", index, err)
+				out <- rec
+				break
+			}
+			rec.Application.Params.GlobalState = globalState
+		}
+
 		out <- rec
 	}
 	if err := rows.Err(); err != nil {
@@ -2423,6 +3045,377 @@ func (db *IndexerDb) yieldApplicationBoxThread(omitValues bool, rows pgx.Rows, o
 	}
 }
 
+// ParticipationUpdates is part of idb.IndexerDB
+func (db *IndexerDb) ParticipationUpdates(ctx context.Context, filter idb.ParticipationUpdateQuery) (<-chan idb.ParticipationUpdateRow, uint64) {
+	out := make(chan idb.ParticipationUpdateRow, 1)
+
+	query := "SELECT round FROM account_participation_update WHERE addr = $1 ORDER BY round DESC"
+	whereArgs := []interface{}{filter.Address}
+
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.ParticipationUpdateRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.ParticipationUpdateRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.ParticipationUpdateRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldParticipationUpdateThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldParticipationUpdateThread(rows pgx.Rows, out chan idb.ParticipationUpdateRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var round uint64
+		err := rows.Scan(&round)
+		if err != nil {
+			out <- idb.ParticipationUpdateRow{Error: err}
+			break
+		}
+		out <- idb.ParticipationUpdateRow{Round: round}
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.ParticipationUpdateRow{Error: err}
+	}
+}
+
+// BalanceHistory is part of idb.IndexerDB
+func (db *IndexerDb) BalanceHistory(ctx context.Context, filter idb.BalanceHistoryQuery) (<-chan idb.BalanceHistoryRow, uint64) {
+	out := make(chan idb.BalanceHistoryRow, 1)
+
+	query := "SELECT round, microalgos FROM account_balance_history WHERE addr = $1"
+	whereArgs := []interface{}{filter.Address}
+
+	if filter.AfterRound != 0 {
+		whereArgs = append(whereArgs, filter.AfterRound)
+		query += fmt.Sprintf(" AND round > $%d", len(whereArgs))
+	}
+	if filter.BeforeRound != 0 {
+		whereArgs = append(whereArgs, filter.BeforeRound)
+		query += fmt.Sprintf(" AND round < $%d", len(whereArgs))
+	}
+	query += " ORDER BY round ASC"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.BalanceHistoryRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.BalanceHistoryRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.BalanceHistoryRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldBalanceHistoryThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldBalanceHistoryThread(rows pgx.Rows, out chan idb.BalanceHistoryRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var round uint64
+		var microalgos uint64
+		err := rows.Scan(&round, &microalgos)
+		if err != nil {
+			out <- idb.BalanceHistoryRow{Error: err}
+			break
+		}
+		out <- idb.BalanceHistoryRow{Round: round, Microalgos: microalgos}
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.BalanceHistoryRow{Error: err}
+	}
+}
+
+// AccountRewards is part of idb.IndexerDB
+func (db *IndexerDb) AccountRewards(ctx context.Context, filter idb.RewardsQuery) (idb.RewardsSummary, uint64, error) {
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		return idb.RewardsSummary{}, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		return idb.RewardsSummary{}, round, err
+	}
+
+	query := `SELECT
+		(SELECT rewards_total FROM account_reward_history
+			WHERE addr = $1 AND round >= $2 ORDER BY round ASC LIMIT 1),
+		(SELECT round FROM account_reward_history
+			WHERE addr = $1 AND round >= $2 ORDER BY round ASC LIMIT 1),
+		(SELECT rewards_total FROM account_reward_history
+			WHERE addr = $1 AND round <= $3 ORDER BY round DESC LIMIT 1),
+		(SELECT round FROM account_reward_history
+			WHERE addr = $1 AND round <= $3 ORDER BY round DESC LIMIT 1)`
+	beforeRound := filter.BeforeRound
+	if beforeRound == 0 {
+		beforeRound = math.MaxInt64
+	}
+
+	row := tx.QueryRow(ctx, query, filter.Address, filter.AfterRound, beforeRound)
+
+	var startRewards, endRewards *uint64
+	var minRound, maxRound *uint64
+	if err := row.Scan(&startRewards, &minRound, &endRewards, &maxRound); err != nil {
+		return idb.RewardsSummary{}, round, err
+	}
+	if startRewards == nil || endRewards == nil {
+		return idb.RewardsSummary{}, round, nil
+	}
+
+	var earned uint64
+	if *endRewards > *startRewards {
+		earned = *endRewards - *startRewards
+	}
+
+	return idb.RewardsSummary{
+		MinRound:      *minRound,
+		MaxRound:      *maxRound,
+		RewardsEarned: earned,
+	}, round, nil
+}
+
+// OnlineStakeHistory is part of idb.IndexerDB
+func (db *IndexerDb) OnlineStakeHistory(ctx context.Context, filter idb.OnlineStakeHistoryQuery) (<-chan idb.OnlineStakeHistoryRow, uint64) {
+	out := make(chan idb.OnlineStakeHistoryRow, 1)
+
+	query := "SELECT round, online_stake FROM network_totals_history"
+	var whereArgs []interface{}
+
+	if filter.AfterRound != 0 {
+		whereArgs = append(whereArgs, filter.AfterRound)
+		query += fmt.Sprintf(" WHERE round > $%d", len(whereArgs))
+	}
+	if filter.BeforeRound != 0 {
+		whereArgs = append(whereArgs, filter.BeforeRound)
+		if len(whereArgs) == 1 {
+			query += fmt.Sprintf(" WHERE round < $%d", len(whereArgs))
+		} else {
+			query += fmt.Sprintf(" AND round < $%d", len(whereArgs))
+		}
+	}
+	query += " ORDER BY round ASC"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.OnlineStakeHistoryRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.OnlineStakeHistoryRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.OnlineStakeHistoryRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldOnlineStakeHistoryThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldOnlineStakeHistoryThread(rows pgx.Rows, out chan idb.OnlineStakeHistoryRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var round uint64
+		var onlineStake uint64
+		err := rows.Scan(&round, &onlineStake)
+		if err != nil {
+			out <- idb.OnlineStakeHistoryRow{Error: err}
+			break
+		}
+		out <- idb.OnlineStakeHistoryRow{Round: round, OnlineStake: onlineStake}
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.OnlineStakeHistoryRow{Error: err}
+	}
+}
+
+// AccountStateDelta is part of idb.IndexerDB. It is backed by the same
+// account_balance_history checkpoints used by BalanceHistory, so it only
+// finds accounts for which EnableBalanceHistory was turned on over the
+// requested round range.
+func (db *IndexerDb) AccountStateDelta(ctx context.Context, filter idb.AccountStateDeltaQuery) (<-chan idb.AccountStateDeltaRow, uint64) {
+	out := make(chan idb.AccountStateDeltaRow, 1)
+
+	changedWhere := "round > $1 AND round <= $2"
+	whereArgs := []interface{}{filter.MinRound, filter.MaxRound}
+	if len(filter.GreaterThanAddress) > 0 {
+		changedWhere += " AND addr > $3"
+		whereArgs = append(whereArgs, filter.GreaterThanAddress)
+	}
+
+	query := fmt.Sprintf(`SELECT
+		changed.addr,
+		COALESCE(
+			(SELECT microalgos FROM account_balance_history h
+				WHERE h.addr = changed.addr AND h.round <= $1 ORDER BY h.round DESC LIMIT 1),
+			0),
+		(SELECT microalgos FROM account_balance_history h
+			WHERE h.addr = changed.addr AND h.round <= $2 ORDER BY h.round DESC LIMIT 1)
+		FROM (
+			SELECT DISTINCT addr FROM account_balance_history
+			WHERE %s
+		) AS changed
+		ORDER BY changed.addr`, changedWhere)
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.AccountStateDeltaRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.AccountStateDeltaRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.AccountStateDeltaRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldAccountStateDeltaThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldAccountStateDeltaThread(rows pgx.Rows, out chan idb.AccountStateDeltaRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var addr []byte
+		var before, after uint64
+		err := rows.Scan(&addr, &before, &after)
+		if err != nil {
+			out <- idb.AccountStateDeltaRow{Error: err}
+			break
+		}
+		out <- idb.AccountStateDeltaRow{Address: addr, BeforeMicroalgos: before, AfterMicroalgos: after}
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.AccountStateDeltaRow{Error: err}
+	}
+}
+
 // AppLocalState is part of idb.IndexerDB
 func (db *IndexerDb) AppLocalState(ctx context.Context, filter idb.ApplicationQuery) (<-chan idb.AppLocalStateRow, uint64) {
 	out := make(chan idb.AppLocalStateRow, 1)
@@ -2488,7 +3481,7 @@ func (db *IndexerDb) AppLocalState(ctx context.Context, filter idb.ApplicationQu
 	}
 
 	go func() {
-		db.yieldAppLocalStateThread(rows, out)
+		db.yieldAppLocalStateThread(ctx, tx, filter.Round, rows, out)
 		// Because we return a channel into a "callWithTimeout" function,
 		// We need to make sure that rollback is called before close()
 		// otherwise we can end up with a situation where "callWithTimeout"
@@ -2501,7 +3494,7 @@ func (db *IndexerDb) AppLocalState(ctx context.Context, filter idb.ApplicationQu
 	return out, round
 }
 
-func (db *IndexerDb) yieldAppLocalStateThread(rows pgx.Rows, out chan idb.AppLocalStateRow) {
+func (db *IndexerDb) yieldAppLocalStateThread(ctx context.Context, tx pgx.Tx, asOfRound *uint64, rows pgx.Rows, out chan idb.AppLocalStateRow) {
 	defer rows.Close()
 
 	for rows.Next() {
@@ -2533,6 +3526,17 @@ func (db *IndexerDb) yieldAppLocalStateThread(rows pgx.Rows, out chan idb.AppLoc
 			NumUint:      ls.Schema.NumUint,
 		}
 		rec.AppLocalState.KeyValue = tealKeyValueToModel(ls.KeyValue)
+
+		if asOfRound != nil {
+			keyValue, err := db.historicalAppState(ctx, tx, index, address, *asOfRound)
+			if err != nil {
+				rec.Error = fmt.Errorf("app=%d addr history err: %w", index, err)
+				out <- rec
+				break
+			}
+			rec.AppLocalState.KeyValue = keyValue
+		}
+
 		out <- rec
 	}
 	if err := rows.Err(); err != nil {
@@ -2550,6 +3554,8 @@ func (db *IndexerDb) Health(ctx context.Context) (idb.Health, error) {
 
 	if db.readonly {
 		data["read-only-mode"] = true
+	} else {
+		data["writer-lock"] = db.writerLockConn != nil
 	}
 
 	if db.migration != nil {
@@ -2573,6 +3579,17 @@ func (db *IndexerDb) Health(ctx context.Context) (idb.Health, error) {
 
 		blocking = migrationStateBlocked(state)
 		migrationRequired = needsMigration(state)
+		data["schema-migration-number"] = state.NextMigration
+		if migrationRequired {
+			data["pending-migrations"] = pendingMigrations(state)
+		}
+
+		schemaCompatible := checkSchemaCompatible(state, db.allowUnsupportedSchema) == nil
+		data["schema-compatible"] = schemaCompatible
+		if !schemaCompatible {
+			blocking = true
+			errString = idb.ErrorSchemaNewerThanBinary.Error()
+		}
 	}
 
 	data["migration-required"] = migrationRequired
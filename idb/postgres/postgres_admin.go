@@ -0,0 +1,65 @@
+// You can build without postgres by `go build --tags nopostgres` but it's on by default
+//go:build !nopostgres
+// +build !nopostgres
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+	"github.com/algorand/indexer/idb/postgres/internal/schema"
+	"github.com/algorand/indexer/idb/postgres/internal/types"
+)
+
+// Metastate is a read-only snapshot of the typed metastate keys an operator
+// is likely to want when diagnosing a stuck or lagging indexer: how far
+// accounting has advanced, which migration it's on, and when data was last
+// pruned. A *types.X field is left nil when its key has never been written
+// (e.g. pruning was never configured), rather than that being an error.
+type Metastate struct {
+	Import    *types.ImportState    `json:"import,omitempty"`
+	Migration *types.MigrationState `json:"migration,omitempty"`
+	Pruning   *types.DeleteStatus   `json:"pruning,omitempty"`
+}
+
+// Metastate reports the current typed metastate, for the admin API to
+// surface at /debug/metastate.
+func (db *IndexerDb) Metastate(ctx context.Context) (Metastate, error) {
+	var res Metastate
+
+	importState, err := db.getImportState(ctx, nil)
+	switch err {
+	case nil:
+		res.Import = &importState
+	case idb.ErrorNotInitialized:
+	default:
+		return Metastate{}, fmt.Errorf("Metastate() import state err: %w", err)
+	}
+
+	migrationState, err := db.getMigrationState(ctx, nil)
+	switch err {
+	case nil:
+		res.Migration = &migrationState
+	case idb.ErrorNotInitialized:
+	default:
+		return Metastate{}, fmt.Errorf("Metastate() migration state err: %w", err)
+	}
+
+	deleteStatusJSON, err := db.getMetastate(ctx, nil, schema.DeleteStatusKey)
+	switch err {
+	case nil:
+		status, decErr := encoding.DecodeDeleteStatus([]byte(deleteStatusJSON))
+		if decErr != nil {
+			return Metastate{}, fmt.Errorf("Metastate() pruning state err: %w", decErr)
+		}
+		res.Pruning = &status
+	case idb.ErrorNotInitialized:
+	default:
+		return Metastate{}, fmt.Errorf("Metastate() pruning state err: %w", err)
+	}
+
+	return res, nil
+}
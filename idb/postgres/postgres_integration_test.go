@@ -1648,6 +1648,39 @@ func TestAddBlockAppOptInOutSameRound(t *testing.T) {
 	assert.Equal(t, uint64(1), *ls.ClosedOutAtRound)
 }
 
+// TestIndexerDb_GetAccountsByApp checks that GetAccounts with HasAppID only
+// returns accounts with local state for that application, i.e. that it
+// enumerates an app's opted-in user base and nothing else.
+func TestIndexerDb_GetAccountsByApp(t *testing.T) {
+	db, shutdownFunc, proc, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appid := uint64(1)
+	createTxn := test.MakeCreateAppTxn(test.AccountA)
+	optInTxn := test.MakeAppOptInTxn(appid, test.AccountB)
+	block, err := test.MakeBlockForTxns(
+		test.MakeGenesisBlock().BlockHeader, &createTxn, &optInTxn)
+	require.NoError(t, err)
+
+	err = proc(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	rowsCh, _ := db.GetAccounts(context.Background(), idb.AccountQueryOptions{
+		HasAppID: appid,
+	})
+	results := getResults(context.Background(), rowsCh)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Error)
+	assert.Equal(t, test.AccountB.String(), results[0].Account.Address)
+
+	rowsCh, _ = db.GetAccounts(context.Background(), idb.AccountQueryOptions{
+		HasAppID: appid + 1,
+	})
+	results = getResults(context.Background(), rowsCh)
+	assert.Nil(t, results)
+}
+
 // TestSearchForInnerTransactionReturnsRootTransaction checks that the parent
 // transaction is returned when matching on inner transactions if the
 // ReturnInnerTxnFlag is false. If the ReturnInnerTxnFlag is true, it should
@@ -2268,6 +2301,64 @@ func TestIndexerDb_GetAccounts(t *testing.T) {
 	}
 }
 
+// TestGetAccountsOrderByBalancePagination checks that paging through
+// order-by=balance results with the composite (value, address) keyset
+// cursor doesn't drop rows whose address happens to sort below the
+// previous page's last address, i.e. that the cursor isn't ANDed with the
+// unconditional address-only cursor used for the default address ordering.
+func TestGetAccountsOrderByBalancePagination(t *testing.T) {
+	db, shutdownFunc, proc, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	// AccountD sorts after AccountA/B/C by balance but before AccountB/C by
+	// address, so a page boundary landing on AccountD's address would wrongly
+	// exclude AccountB and AccountC if the old address-only cursor were still
+	// applied alongside the new composite one.
+	txnD := test.MakePaymentTxn(
+		1000, 100, 0, 0, 0, 0, test.AccountA, test.AccountD, basics.Address{}, basics.Address{})
+	txnB := test.MakePaymentTxn(
+		1000, 200, 0, 0, 0, 0, test.AccountA, test.AccountB, basics.Address{}, basics.Address{})
+	txnC := test.MakePaymentTxn(
+		1000, 300, 0, 0, 0, 0, test.AccountA, test.AccountC, basics.Address{}, basics.Address{})
+	block, err := test.MakeBlockForTxns(test.MakeGenesisBlock().BlockHeader, &txnD, &txnB, &txnC)
+	require.NoError(t, err)
+	err = proc(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	// Page 1: lowest two balances, AccountA then AccountD.
+	rowsCh, _ := db.GetAccounts(context.Background(), idb.AccountQueryOptions{
+		OrderBy: idb.AccountOrderByBalance,
+		Limit:   2,
+	})
+	page1 := getResults(context.Background(), rowsCh)
+	require.Len(t, page1, 2)
+	require.NoError(t, page1[0].Error)
+	require.NoError(t, page1[1].Error)
+	assert.Equal(t, test.AccountA.String(), page1[0].Account.Address)
+	assert.Equal(t, test.AccountD.String(), page1[1].Account.Address)
+
+	last := page1[1]
+	cursorAddr, err := basics.UnmarshalChecksumAddress(last.Account.Address)
+	require.NoError(t, err)
+	cursorValue := last.Account.Amount
+
+	// Page 2 must still contain AccountB and AccountC, even though both
+	// sort before AccountD's address.
+	rowsCh, _ = db.GetAccounts(context.Background(), idb.AccountQueryOptions{
+		OrderBy:               idb.AccountOrderByBalance,
+		GreaterThanOrderValue: &cursorValue,
+		GreaterThanAddress:    cursorAddr[:],
+		Limit:                 2,
+	})
+	page2 := getResults(context.Background(), rowsCh)
+	require.Len(t, page2, 2)
+	require.NoError(t, page2[0].Error)
+	require.NoError(t, page2[1].Error)
+	assert.Equal(t, test.AccountB.String(), page2[0].Account.Address)
+	assert.Equal(t, test.AccountC.String(), page2[1].Account.Address)
+}
+
 // Test that AddBlock() writes to `txn_participation` table.
 func TestTransactionFilterAssetAmount(t *testing.T) {
 	block := test.MakeGenesisBlock()
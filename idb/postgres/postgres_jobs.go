@@ -0,0 +1,190 @@
+// You can build without postgres by `go build --tags nopostgres` but it's on by default
+//go:build !nopostgres
+// +build !nopostgres
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Job kinds recognized by the background job framework. Maintenance tasks
+// register their own kind constant here as they adopt it; pruning is the
+// first consumer, with rollups and backfills expected to follow.
+const (
+	// JobKindPrune is used by the conduit postgresql exporter's data
+	// pruning loop.
+	JobKindPrune = "prune"
+)
+
+// JobStatus is the lifecycle state of a row in the job table.
+type JobStatus string
+
+// JobStatus values. ClaimJob creates or re-leases a job as running, and it
+// ends done (FinishJob) or failed (FailJob); CancelJob moves a running job to
+// cancel-requested, which FinishJob then marks done. None of the functions
+// in this file set JobStatusIdle.
+const (
+	JobStatusIdle            JobStatus = "idle"
+	JobStatusRunning         JobStatus = "running"
+	JobStatusCancelRequested JobStatus = "cancel-requested"
+	JobStatusDone            JobStatus = "done"
+	JobStatusFailed          JobStatus = "failed"
+)
+
+// Job is one named, resumable unit of background maintenance work: pruning,
+// rollups, backfills, or anything else that needs to survive a process
+// restart partway through. Progress is an opaque JSON blob so each job kind
+// can track whatever state it needs (e.g. the round pruned through so far)
+// without a schema change; the job framework itself only understands
+// leasing, progress, and retries.
+type Job struct {
+	Name       string
+	Kind       string
+	Status     JobStatus
+	Progress   json.RawMessage
+	LeaseOwner string
+	LeaseUntil time.Time
+	Attempt    int
+	LastError  string
+	UpdatedAt  time.Time
+}
+
+// ErrJobNotOwned is returned by HeartbeatJob, FinishJob, and FailJob when
+// the caller's lease has already expired or been claimed by someone else,
+// so a job runner knows to stop working rather than keep writing progress
+// nobody will read.
+var ErrJobNotOwned = errors.New("job is not owned by the given owner")
+
+const jobColumns = "name, kind, status, progress, lease_owner, lease_until, attempt, coalesce(last_error, ''), updated_at"
+
+func scanJob(row pgx.Row) (Job, error) {
+	var j Job
+	err := row.Scan(
+		&j.Name, &j.Kind, &j.Status, &j.Progress, &j.LeaseOwner, &j.LeaseUntil,
+		&j.Attempt, &j.LastError, &j.UpdatedAt)
+	return j, err
+}
+
+// ClaimJob leases the named job for owner until lease elapses, creating it
+// with kind and empty progress if it doesn't already exist. It returns
+// ok=false (and no error) if another owner currently holds an unexpired
+// lease, so a caller polling on an interval can just skip this round
+// instead of racing for it.
+func (db *IndexerDb) ClaimJob(ctx context.Context, name, kind, owner string, lease time.Duration) (Job, bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO job (name, kind, status, progress, lease_owner, lease_until, attempt, updated_at)
+		VALUES ($1, $2, 'running', '{}', $3, $4, 1, now())
+		ON CONFLICT (name) DO UPDATE SET
+			status = 'running',
+			lease_owner = excluded.lease_owner,
+			lease_until = excluded.lease_until,
+			attempt = job.attempt + 1,
+			updated_at = now()
+		WHERE job.lease_until < now() OR job.lease_owner = excluded.lease_owner
+		RETURNING %s`, jobColumns)
+
+	row := db.db.QueryRow(ctx, query, name, kind, owner, time.Now().UTC().Add(lease))
+	job, err := scanJob(row)
+	if err == pgx.ErrNoRows {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, fmt.Errorf("ClaimJob(): err %w", err)
+	}
+	return job, true, nil
+}
+
+// HeartbeatJob extends an owned, running job's lease and records its
+// progress blob, letting it resume from where it left off instead of
+// restarting from scratch if the process dies before it finishes. It
+// returns the job's current status so the caller notices a
+// cancel-requested job (set by CancelJob) and can stop cooperatively
+// instead of being killed mid-write.
+func (db *IndexerDb) HeartbeatJob(ctx context.Context, name, owner string, lease time.Duration, progress json.RawMessage) (JobStatus, error) {
+	var status JobStatus
+	row := db.db.QueryRow(ctx, `
+		UPDATE job SET lease_until = $1, progress = $2, updated_at = now()
+		WHERE name = $3 AND lease_owner = $4 AND status IN ('running', 'cancel-requested')
+		RETURNING status`,
+		time.Now().UTC().Add(lease), []byte(progress), name, owner)
+	err := row.Scan(&status)
+	if err == pgx.ErrNoRows {
+		return "", ErrJobNotOwned
+	}
+	if err != nil {
+		return "", fmt.Errorf("HeartbeatJob(): err %w", err)
+	}
+	return status, nil
+}
+
+// FinishJob marks an owned job done and releases its lease.
+func (db *IndexerDb) FinishJob(ctx context.Context, name, owner string) error {
+	cmd, err := db.db.Exec(ctx, `
+		UPDATE job SET status = 'done', lease_owner = '', lease_until = 'epoch', updated_at = now()
+		WHERE name = $1 AND lease_owner = $2`, name, owner)
+	if err != nil {
+		return fmt.Errorf("FinishJob(): err %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrJobNotOwned
+	}
+	return nil
+}
+
+// FailJob records an owned job's error, leaves its attempt count as already
+// incremented by ClaimJob, and releases its lease so a future ClaimJob call
+// can retry it.
+func (db *IndexerDb) FailJob(ctx context.Context, name, owner string, jobErr error) error {
+	cmd, err := db.db.Exec(ctx, `
+		UPDATE job SET status = 'failed', lease_owner = '', lease_until = 'epoch', last_error = $3, updated_at = now()
+		WHERE name = $1 AND lease_owner = $2`, name, owner, jobErr.Error())
+	if err != nil {
+		return fmt.Errorf("FailJob(): err %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrJobNotOwned
+	}
+	return nil
+}
+
+// CancelJob requests that a running job stop at its next heartbeat, by
+// marking it cancel-requested. It's a no-op, returning no error, if the job
+// doesn't exist or isn't currently running.
+func (db *IndexerDb) CancelJob(ctx context.Context, name string) error {
+	_, err := db.db.Exec(ctx, `
+		UPDATE job SET status = 'cancel-requested', updated_at = now()
+		WHERE name = $1 AND status = 'running'`, name)
+	if err != nil {
+		return fmt.Errorf("CancelJob(): err %w", err)
+	}
+	return nil
+}
+
+// ListJobs returns every job row, for the admin API's job-status endpoint.
+func (db *IndexerDb) ListJobs(ctx context.Context) ([]Job, error) {
+	rows, err := db.db.Query(ctx, fmt.Sprintf("SELECT %s FROM job ORDER BY name", jobColumns))
+	if err != nil {
+		return nil, fmt.Errorf("ListJobs(): err %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ListJobs(): scan err %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListJobs(): err %w", err)
+	}
+	return jobs, nil
+}
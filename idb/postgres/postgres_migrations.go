@@ -53,6 +53,23 @@ func init() {
 
 		// Migration for app box support
 		{createAppBoxTable, true, "add new table app_box for application boxes"},
+		{addAccountActivityColumns, true, "add account.total_transactions and account.last_active_round"},
+		{addAssetNumHoldersColumn, true, "add asset.num_holders"},
+		{createAppCallStatsTables, true, "add new tables app_caller and app_call_stats"},
+		{addTxnNotePrefixColumn, true, "add txn.note_prefix and its index"},
+		{shrinkTxnByTxidIndex, true, "make txn_by_tixid a partial index excluding inner transactions"},
+		{addLogicSigAndMultisigParticipationColumns, true, "add txn.lsig_hash and txn_multisig_participation"},
+		{createAccountParticipationUpdateTable, true, "add new table account_participation_update"},
+		{createAppStateHistoryTable, true, "add new table app_state_history"},
+		{createAccountBalanceHistoryTable, true, "add new table account_balance_history"},
+		{createAccountRewardHistoryTable, true, "add new table account_reward_history"},
+		{addAccountOrderingIndexes, true, "add indexes to support ordering /v2/accounts results by balance, created_at, or last_active_round"},
+		{createJobTable, true, "add new table job for resumable background maintenance tasks"},
+		{addAccountHeartbeatColumn, true, "add account.last_heartbeat_round"},
+		{createNetworkTotalsHistoryTable, true, "add new table network_totals_history"},
+		{addTxnFeeColumn, true, "add txn.fee and its index"},
+		{addClosingTxidColumns, true, "add asset.closing_txid and app.closing_txid"},
+		{addMetastateVersionColumn, true, "add metastate.version"},
 	}
 }
 
@@ -91,6 +108,44 @@ func needsMigration(state types.MigrationState) bool {
 	return state.NextMigration < len(migrations)
 }
 
+// schemaNewerThanBinary returns true if the DB's migration number is past
+// the last migration this binary knows about, meaning it was last migrated
+// by a newer indexer release that this binary can't safely run against.
+func schemaNewerThanBinary(state types.MigrationState) bool {
+	return state.NextMigration > len(migrations)
+}
+
+// checkSchemaCompatible returns idb.ErrorSchemaNewerThanBinary if state
+// comes from a migration this binary doesn't know about. Running against
+// it is undefined behavior, so callers should refuse to start; a read-only
+// caller may pass allowUnsupported to serve reads anyway.
+func checkSchemaCompatible(state types.MigrationState, allowUnsupported bool) error {
+	if schemaNewerThanBinary(state) && !allowUnsupported {
+		return idb.ErrorSchemaNewerThanBinary
+	}
+	return nil
+}
+
+// PendingMigration describes one migration that has not yet run, for
+// reporting to operators (e.g. via Health's Data map) without running it.
+type PendingMigration struct {
+	Description string `json:"description"`
+	Blocking    bool   `json:"blocking"`
+}
+
+// pendingMigrations returns, in run order, the migrations that still need to
+// run for state.
+func pendingMigrations(state types.MigrationState) []PendingMigration {
+	var pending []PendingMigration
+	for i := state.NextMigration; i < len(migrations); i++ {
+		pending = append(pending, PendingMigration{
+			Description: migrations[i].description,
+			Blocking:    migrations[i].blocking,
+		})
+	}
+	return pending
+}
+
 // Returns an error object and a channel that gets closed when blocking migrations
 // finish running successfully.
 func (db *IndexerDb) runAvailableMigrations(opts idb.IndexerDbOptions) (chan struct{}, error) {
@@ -101,6 +156,12 @@ func (db *IndexerDb) runAvailableMigrations(opts idb.IndexerDbOptions) (chan str
 		return nil, fmt.Errorf("runAvailableMigrations() err: %w", err)
 	}
 
+	// A writer never gets the read-only override: running accounting logic
+	// against a schema a newer release produced is undefined behavior.
+	if err := checkSchemaCompatible(state, false); err != nil {
+		return nil, fmt.Errorf("runAvailableMigrations() err: %w", err)
+	}
+
 	// Make migration tasks
 	nextMigration := state.NextMigration
 	tasks := make([]migration.Task, 0)
@@ -114,16 +175,10 @@ func (db *IndexerDb) runAvailableMigrations(opts idb.IndexerDbOptions) (chan str
 		nextMigration++
 	}
 
-	if len(tasks) > 0 {
-		// Add a task to mark migrations as done instead of using a channel.
-		tasks = append(tasks, migration.Task{
-			MigrationID: 9999999,
-			Handler: func() error {
-				return db.markMigrationsAsDone()
-			},
-			Description: "Mark migrations done",
-		})
-	}
+	// Each task above already persists its own NextMigration via
+	// setMigrationState/sqlMigration as it completes, so there's no separate
+	// finalization write needed here; markMigrationsAsDone's CAS-against-no-row
+	// is only valid for the brand new database path in init().
 
 	db.migration, err = migration.MakeMigration(tasks, db.log)
 	if err != nil {
@@ -134,13 +189,18 @@ func (db *IndexerDb) runAvailableMigrations(opts idb.IndexerDbOptions) (chan str
 	return ch, nil
 }
 
-// after setting up a new database, mark state as if all migrations had been done
+// after setting up a new database, mark state as if all migrations had been
+// done. This runs immediately after creating the metastate table, so the
+// migration row can't exist yet; the CAS write (expecting version 0, i.e. no
+// row) makes sure that if two processes both see a fresh database and race
+// to initialize it, only one of them wins instead of silently overwriting
+// the other's write.
 func (db *IndexerDb) markMigrationsAsDone() (err error) {
 	state := types.MigrationState{
 		NextMigration: len(migrations),
 	}
 	migrationStateJSON := encoding.EncodeMigrationState(&state)
-	return db.setMetastate(nil, schema.MigrationMetastateKey, string(migrationStateJSON))
+	return db.setMetastateCAS(nil, schema.MigrationMetastateKey, string(migrationStateJSON), 0)
 }
 
 // Returns `idb.ErrorNotInitialized` if uninitialized.
@@ -174,6 +234,7 @@ func (db *IndexerDb) setMigrationState(tx pgx.Tx, state *types.MigrationState) e
 }
 
 // sqlMigration executes a sql statements as the entire migration.
+//
 //lint:ignore U1000 this function might be used in a future migration
 func sqlMigration(db *IndexerDb, state *types.MigrationState, sqlLines []string) error {
 	db.accountingLock.Lock()
@@ -210,6 +271,7 @@ func sqlMigration(db *IndexerDb, state *types.MigrationState, sqlLines []string)
 const unsupportedMigrationErrorMsg = "unsupported migration: please downgrade to %s to run this migration"
 
 // disabled creates a simple migration handler for unsupported migrations.
+//
 //lint:ignore U1000 this function might be used in the future
 func disabled(version string) func(db *IndexerDb, migrationState *types.MigrationState) error {
 	return func(_ *IndexerDb, _ *types.MigrationState) error {
@@ -262,3 +324,284 @@ func createAppBoxTable(db *IndexerDb, migrationState *types.MigrationState, opts
 			PRIMARY KEY (app, name)
 		)`})
 }
+
+// addAccountActivityColumns adds the columns the writer uses to maintain a
+// running per-account transaction count and last-active round, so the API
+// can serve them without a COUNT(*) over txn_participation. Existing
+// accounts start at zero; their historical activity prior to this migration
+// is not backfilled, since doing so would require exactly the slow
+// full-table scan this feature exists to avoid.
+func addAccountActivityColumns(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE account ADD COLUMN IF NOT EXISTS total_transactions bigint NOT NULL DEFAULT 0`,
+			`ALTER TABLE account ADD COLUMN IF NOT EXISTS last_active_round bigint NOT NULL DEFAULT 0`,
+		})
+}
+
+// addAssetNumHoldersColumn adds the column the writer uses to maintain a
+// running opt-in count per asset, so /v2/assets can serve holder counts
+// without a COUNT(*) over account_asset. Existing assets start at zero;
+// their historical holder count prior to this migration is not backfilled,
+// since that would require exactly the slow full-table scan this feature
+// exists to avoid.
+func addAssetNumHoldersColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE asset ADD COLUMN IF NOT EXISTS num_holders bigint NOT NULL DEFAULT 0`,
+		})
+}
+
+// createAppCallStatsTables adds app_caller and app_call_stats, which the
+// writer uses to maintain per-application call and unique-caller counts
+// without a COUNT(DISTINCT ...) over txn. Existing apps start at zero;
+// their historical call activity prior to this migration is not
+// backfilled, since doing so would require exactly the slow full-table scan
+// this feature exists to avoid.
+func createAppCallStatsTables(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS app_caller (
+				app bigint NOT NULL,
+				caller bytea NOT NULL,
+				PRIMARY KEY (app, caller)
+			)`,
+			`CREATE TABLE IF NOT EXISTS app_call_stats (
+				app bigint PRIMARY KEY,
+				call_count bigint NOT NULL DEFAULT 0,
+				unique_callers bigint NOT NULL DEFAULT 0
+			)`,
+		})
+}
+
+// addTxnNotePrefixColumn adds txn.note_prefix and its partial index, so
+// note-prefix search can use an index scan instead of decoding and
+// substring-matching every row's note. Existing rows are left with a NULL
+// note_prefix; backfilling it would require exactly the slow full-table
+// decode this feature exists to avoid, so only newly imported transactions
+// get it populated.
+func addTxnNotePrefixColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE txn ADD COLUMN IF NOT EXISTS note_prefix bytea`,
+			`CREATE INDEX IF NOT EXISTS txn_by_note_prefix ON txn ( note_prefix ) WHERE note_prefix IS NOT NULL`,
+		})
+}
+
+// shrinkTxnByTxidIndex rebuilds txn_by_tixid as a partial index that skips
+// inner transactions, which always have a NULL txid. Inner transactions
+// typically outnumber root transactions by a wide margin, so indexing their
+// NULL txid bloats the index without ever being useful: lookups are always
+// by a specific txid, never "txid IS NULL". Shrinking it keeps
+// GET /v2/transactions/{txid} a single cheap index lookup as history grows.
+func shrinkTxnByTxidIndex(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`DROP INDEX IF EXISTS txn_by_tixid`,
+			`CREATE INDEX IF NOT EXISTS txn_by_tixid ON txn ( txid ) WHERE txid IS NOT NULL`,
+		})
+}
+
+// addLogicSigAndMultisigParticipationColumns adds txn.lsig_hash, for
+// searching by LogicSig program hash, and txn_multisig_participation, for
+// searching by multisig subsigner address. Existing rows are left without
+// this data; backfilling would require decoding every row's txn column, the
+// same cost this feature exists to let future searches avoid.
+func addLogicSigAndMultisigParticipationColumns(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE txn ADD COLUMN IF NOT EXISTS lsig_hash bytea`,
+			`CREATE INDEX IF NOT EXISTS txn_by_lsig_hash ON txn ( lsig_hash ) WHERE lsig_hash IS NOT NULL`,
+			`CREATE TABLE IF NOT EXISTS txn_multisig_participation (
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				intra integer NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS txn_multisig_participation_i ON txn_multisig_participation ( addr, round DESC, intra DESC )`,
+		})
+}
+
+// createAccountParticipationUpdateTable adds account_participation_update,
+// which the writer populates from each block's
+// participationUpdates.expired-participation-accounts so that the rounds at
+// which a given account was marked expired can be looked up directly instead
+// of scanning every block_header.
+func createAccountParticipationUpdateTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS account_participation_update (
+				addr bytea NOT NULL,
+				round bigint NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS account_participation_update_addr ON account_participation_update ( addr, round DESC )`,
+		})
+}
+
+// createAppStateHistoryTable adds app_state_history, which the writer
+// populates with one row per changed application state key per round when
+// IndexerDbOptions.EnableAppStateHistory is turned on, so application/local
+// state lookups can ask for state as of a past round. Existing rows are left
+// without history; state as of a round before history tracking was enabled
+// cannot be reconstructed.
+func createAppStateHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS app_state_history (
+				round bigint NOT NULL,
+				intra integer NOT NULL,
+				app bigint NOT NULL,
+				addr bytea,
+				key bytea NOT NULL,
+				action smallint NOT NULL,
+				value_bytes bytea,
+				value_uint numeric(20)
+			)`,
+			`CREATE INDEX IF NOT EXISTS app_state_history_by_key ON app_state_history ( app, key, round DESC, intra DESC )`,
+		})
+}
+
+// createAccountBalanceHistoryTable adds account_balance_history, which the
+// writer populates with one row per round in which an account's data was
+// updated when IndexerDbOptions.EnableBalanceHistory is turned on, so
+// GET /v2/accounts/{addr}/balance-history can return a time series. Existing
+// rows are left without history; balance history before tracking was enabled
+// cannot be reconstructed.
+func createAccountBalanceHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS account_balance_history (
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				microalgos bigint NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS account_balance_history_by_addr ON account_balance_history ( addr, round DESC )`,
+		})
+}
+
+// createAccountRewardHistoryTable adds account_reward_history, which the
+// writer populates with one row per round in which an account's data was
+// updated when IndexerDbOptions.EnableRewardsHistory is turned on, so
+// AccountRewards can summarize rewards earned over a round range. Existing
+// rows are left without history; rewards earned before tracking was enabled
+// cannot be reconstructed.
+func createAccountRewardHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS account_reward_history (
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				rewards_total bigint NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS account_reward_history_by_addr ON account_reward_history ( addr, round DESC )`,
+		})
+}
+
+// addAccountOrderingIndexes adds the indexes GetAccounts needs to sort and
+// keyset-page by balance, created_at, or last_active_round instead of only
+// address, for leaderboard and "recently active" dashboard queries. They
+// exclude deleted accounts, matching the "NOT a.deleted" filter GetAccounts
+// always applies unless IncludeDeleted is set, so a deleted account with a
+// large balance doesn't bloat the balance index for no benefit.
+func addAccountOrderingIndexes(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE INDEX IF NOT EXISTS account_by_microalgos ON account ( microalgos, addr ) WHERE NOT deleted`,
+			`CREATE INDEX IF NOT EXISTS account_by_created_at ON account ( created_at, addr ) WHERE NOT deleted`,
+			`CREATE INDEX IF NOT EXISTS account_by_last_active_round ON account ( last_active_round, addr ) WHERE NOT deleted`,
+		})
+}
+
+// createJobTable adds job, the generic background-job bookkeeping table
+// described in postgres_jobs.go. Pruning is the first task to adopt it;
+// rollups and backfills are expected to register their own job kinds
+// against the same table rather than inventing their own lease/progress
+// scheme.
+func createJobTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS job (
+				name text PRIMARY KEY,
+				kind text NOT NULL,
+				status text NOT NULL DEFAULT 'idle',
+				progress jsonb NOT NULL DEFAULT '{}'::jsonb,
+				lease_owner text NOT NULL DEFAULT '',
+				lease_until timestamp without time zone NOT NULL DEFAULT 'epoch',
+				attempt integer NOT NULL DEFAULT 0,
+				last_error text,
+				updated_at timestamp without time zone NOT NULL DEFAULT now()
+			)`,
+		})
+}
+
+// addAccountHeartbeatColumn adds the column the writer uses to track the
+// round of each account's most recent key registration transaction, as a
+// proxy for online participation liveness (block proposer data isn't part
+// of the imported data, so keyreg transactions are the only available
+// heartbeat signal). Existing accounts start at 0, meaning "never
+// heartbeated", same as a brand new account; there is no way to backfill
+// it for keyreg transactions imported before this migration without a
+// full txn table scan, so it is left for rounds imported from here on.
+func addAccountHeartbeatColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE account ADD COLUMN IF NOT EXISTS last_heartbeat_round bigint NOT NULL DEFAULT 0`,
+			`CREATE INDEX IF NOT EXISTS account_by_last_heartbeat_round ON account ( last_heartbeat_round, addr ) WHERE NOT deleted`,
+		})
+}
+
+// createNetworkTotalsHistoryTable adds network_totals_history, which the
+// writer populates with one row per round recording the network's total
+// online stake when IndexerDbOptions.EnableOnlineStakeHistory is turned on,
+// so it can be served as a time series for governance and network-health
+// dashboards. Existing rounds are left without history; online stake before
+// tracking was enabled cannot be reconstructed.
+func createNetworkTotalsHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS network_totals_history (
+				round bigint PRIMARY KEY,
+				online_stake bigint NOT NULL
+			)`,
+		})
+}
+
+// addTxnFeeColumn adds txn.fee and its partial index, so min-fee/max-fee
+// search can use an index scan instead of decoding every row's txn json.
+// Existing rows are left with a NULL fee; backfilling it would require
+// exactly the slow full-table decode this feature exists to avoid, so only
+// newly imported transactions get it populated.
+func addTxnFeeColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE txn ADD COLUMN IF NOT EXISTS fee bigint`,
+			`CREATE INDEX IF NOT EXISTS txn_by_fee ON txn ( fee ) WHERE fee IS NOT NULL`,
+		})
+}
+
+// addClosingTxidColumns adds asset.closing_txid and app.closing_txid, which
+// the writer populates with the txid of the top-level transaction that
+// destroyed the asset/app, so an include-all lookup on a deleted resource
+// can report what destroyed it alongside its now-retained last-known
+// params. Existing deleted rows are left with a NULL closing_txid and a
+// params value of "null", since neither can be reconstructed without
+// replaying history from before this migration.
+func addClosingTxidColumns(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE asset ADD COLUMN IF NOT EXISTS closing_txid bytea`,
+			`ALTER TABLE app ADD COLUMN IF NOT EXISTS closing_txid bytea`,
+		})
+}
+
+// addMetastateVersionColumn adds metastate.version, which util.SetMetastateCAS
+// uses to detect a lost update when two processes race on the same key (most
+// importantly migration state, when more than one indexer starts up against
+// a fresh database at once). Existing rows default to version 0, which is
+// indistinguishable from a freshly-inserted row, so the first CAS write
+// against a pre-migration row is treated the same as against no row at all.
+func addMetastateVersionColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`ALTER TABLE metastate ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 0`,
+		})
+}
@@ -3,12 +3,15 @@ package postgres
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/data/basics"
 
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/migration"
 	pgtest "github.com/algorand/indexer/idb/postgres/internal/testing"
 	"github.com/algorand/indexer/idb/postgres/internal/types"
 )
@@ -35,6 +38,51 @@ func TestConvertAccountDataIncrementsMigrationNumber(t *testing.T) {
 	assert.Equal(t, types.MigrationState{NextMigration: 6}, migrationState)
 }
 
+// TestRunAvailableMigrationsSucceedsWithoutFinalizationConflict checks that
+// running the remaining migrations against a database that already has some
+// migrations recorded as done doesn't fail. Each real migration step persists
+// its own NextMigration via the plain (non-CAS) setMetastate/sqlMigration
+// path, so a redundant finalization write expecting version 0 would always
+// lose the race against that and report a spurious failure.
+func TestRunAvailableMigrationsSucceedsWithoutFinalizationConflict(t *testing.T) {
+	pdb, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	db := IndexerDb{db: pdb}
+	defer db.Close()
+
+	migrationState := types.MigrationState{NextMigration: 1}
+	err := db.setMigrationState(nil, &migrationState)
+	require.NoError(t, err)
+
+	noop := func(db *IndexerDb, state *types.MigrationState, opts *idb.IndexerDbOptions) error {
+		return sqlMigration(db, state, []string{"SELECT 1"})
+	}
+	origMigrations := migrations
+	defer func() { migrations = origMigrations }()
+	migrations = []migrationStruct{
+		{noop, false, "already done"},
+		{noop, false, "the one remaining migration"},
+	}
+
+	ch, err := db.runAvailableMigrations(idb.IndexerDbOptions{})
+	require.NoError(t, err)
+	<-ch
+
+	deadline := time.Now().Add(10 * time.Second)
+	for db.migration.GetStatus().Status != migration.StatusComplete && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	status := db.migration.GetStatus()
+	assert.NoError(t, status.Err)
+	assert.Equal(t, migration.StatusComplete, status.Status)
+
+	finalState, err := db.getMigrationState(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.MigrationState{NextMigration: 2}, finalState)
+}
+
 func TestCreateAppBoxTable(t *testing.T) {
 	pdb, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
 	defer shutdownFunc()
@@ -25,6 +25,7 @@ func RegisterPrometheusMetrics(subsystem string) {
 	_ = prometheus.Register(ProcessorTimeSeconds)
 	_ = prometheus.Register(ExporterTimeSeconds)
 	_ = prometheus.Register(PipelineRetryCount)
+	_ = prometheus.Register(AlgodHealthGauge)
 }
 func deregister() {
 	// Use ImportedTxns as a sentinel value. None or all should be initialized.
@@ -39,6 +40,7 @@ func deregister() {
 		prometheus.Unregister(ProcessorTimeSeconds)
 		prometheus.Unregister(ExporterTimeSeconds)
 		prometheus.Unregister(PipelineRetryCount)
+		prometheus.Unregister(AlgodHealthGauge)
 	}
 }
 
@@ -111,6 +113,14 @@ func instantiateCollectors(subsystem string) {
 			Name:      PipelineRetryCountName,
 			Help:      "Total pipeline retries since last successful run",
 		})
+
+	AlgodHealthGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      AlgodHealthGaugeName,
+			Help:      "1 if the fetcher's circuit breaker considers algod healthy, 0 if it has tripped after repeated failures.",
+		})
+	AlgodHealthGauge.Set(1)
 }
 
 // Prometheus metric names broken out for reuse.
@@ -124,6 +134,7 @@ const (
 	ProcessorTimeName        = "processor_time_sec"
 	ExporterTimeName         = "exporter_time_sec"
 	PipelineRetryCountName   = "pipeline_retry_count"
+	AlgodHealthGaugeName     = "algod_health"
 )
 
 // AllMetricNames is a reference for all the custom metric names.
@@ -136,6 +147,7 @@ var AllMetricNames = []string{
 	ProcessorTimeName,
 	ExporterTimeName,
 	PipelineRetryCountName,
+	AlgodHealthGaugeName,
 }
 
 // Initialize the prometheus objects.
@@ -154,4 +166,5 @@ var (
 	ProcessorTimeSeconds   *prometheus.SummaryVec
 	ExporterTimeSeconds    prometheus.Summary
 	PipelineRetryCount     prometheus.Histogram
+	AlgodHealthGauge       prometheus.Gauge
 )
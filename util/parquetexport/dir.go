@@ -0,0 +1,8 @@
+package parquetexport
+
+import "os"
+
+// ensureDir creates dir, including parents, if it doesn't already exist.
+func ensureDir(dir string) error {
+	return os.MkdirAll(dir, 0755)
+}
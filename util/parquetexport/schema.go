@@ -0,0 +1,161 @@
+// Package parquetexport converts indexer transaction and account rows into
+// a flat, documented Parquet schema and writes them out as partitioned
+// files, so data teams can load indexer data into Spark/BigQuery without
+// writing custom ETL against the Postgres schema or the JSON API.
+package parquetexport
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	sdk "github.com/algorand/go-algorand-sdk/types"
+
+	models "github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/idb"
+)
+
+// TransactionRow is one row of the transactions Parquet schema. It
+// flattens the handful of fields common to every transaction plus the
+// payment/asset-transfer/asset-config/application-call fields that apply
+// to that transaction's type; fields that don't apply to a given
+// transaction's type are left at their zero value.
+type TransactionRow struct {
+	// Round is the round the transaction was committed in.
+	Round int64 `parquet:"name=round, type=INT64"`
+	// Intra is the transaction's offset within its round's block, or for an
+	// inner transaction, the offset of its outermost (root) transaction.
+	Intra int32 `parquet:"name=intra, type=INT32"`
+	// RoundTime is the block's confirmation time, Unix seconds.
+	RoundTime int64 `parquet:"name=round_time, type=INT64"`
+	// Txid is the transaction ID, or for an inner transaction, the ID of
+	// its outermost (root) transaction.
+	Txid string `parquet:"name=txid, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// TxType is the transaction type: pay, keyreg, acfg, axfer, afrz, appl, or stpf.
+	TxType string `parquet:"name=tx_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// Sender is the transaction's sending account.
+	Sender string `parquet:"name=sender, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// FeeMicroalgos is the fee paid by the transaction, in microAlgos.
+	FeeMicroalgos int64 `parquet:"name=fee_microalgos, type=INT64"`
+	// AssetID is the ID of any asset or application created or configured
+	// by this transaction, 0 if none.
+	AssetID int64 `parquet:"name=asset_id, type=INT64"`
+
+	// PaymentReceiver is the receiver of a pay transaction.
+	PaymentReceiver string `parquet:"name=payment_receiver, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// PaymentAmountMicroalgos is the amount moved by a pay transaction, in microAlgos.
+	PaymentAmountMicroalgos int64 `parquet:"name=payment_amount_microalgos, type=INT64"`
+	// PaymentCloseAmountMicroalgos is the sender's balance swept to
+	// PaymentCloseTo when a pay transaction closes the sending account.
+	PaymentCloseAmountMicroalgos int64 `parquet:"name=payment_close_amount_microalgos, type=INT64"`
+	// PaymentCloseTo is the close-remainder-to address of a pay transaction, if set.
+	PaymentCloseTo string `parquet:"name=payment_close_to, type=BYTE_ARRAY, convertedtype=UTF8"`
+
+	// AssetTransferReceiver is the receiver of an axfer transaction.
+	AssetTransferReceiver string `parquet:"name=asset_transfer_receiver, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// AssetTransferAmount is the asset amount moved by an axfer transaction.
+	AssetTransferAmount int64 `parquet:"name=asset_transfer_amount, type=INT64"`
+	// AssetTransferCloseAmount is the sender's remaining asset holding
+	// swept to AssetTransferCloseTo when an axfer transaction closes it out.
+	AssetTransferCloseAmount int64 `parquet:"name=asset_transfer_close_amount, type=INT64"`
+	// AssetTransferCloseTo is the asset-close-to address of an axfer transaction, if set.
+	AssetTransferCloseTo string `parquet:"name=asset_transfer_close_to, type=BYTE_ARRAY, convertedtype=UTF8"`
+
+	// ApplicationID is the app ID targeted by an appl transaction (0 for creations).
+	ApplicationID int64 `parquet:"name=application_id, type=INT64"`
+}
+
+// AccountRow is one row of the accounts Parquet schema: a flattened
+// current-balance snapshot, not a history of the account over time.
+type AccountRow struct {
+	// Round is the round the snapshot was taken at.
+	Round int64 `parquet:"name=round, type=INT64"`
+	// Address is the account's address.
+	Address string `parquet:"name=address, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// AmountMicroalgos is the account's current balance, in microAlgos.
+	AmountMicroalgos int64 `parquet:"name=amount_microalgos, type=INT64"`
+	// PendingRewardsMicroalgos is the not-yet-applied rewards owed to the account.
+	PendingRewardsMicroalgos int64 `parquet:"name=pending_rewards_microalgos, type=INT64"`
+	// RewardsMicroalgos is the total rewards, including pending, the account has received.
+	RewardsMicroalgos int64 `parquet:"name=rewards_microalgos, type=INT64"`
+	// Status is the account's participation status: Online, Offline, or NotParticipating.
+	Status string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	// TotalAppsOptedIn is the number of applications the account has opted into.
+	TotalAppsOptedIn int64 `parquet:"name=total_apps_opted_in, type=INT64"`
+	// TotalAssetsOptedIn is the number of assets the account holds.
+	TotalAssetsOptedIn int64 `parquet:"name=total_assets_opted_in, type=INT64"`
+	// TotalCreatedApps is the number of applications the account created.
+	TotalCreatedApps int64 `parquet:"name=total_created_apps, type=INT64"`
+	// TotalCreatedAssets is the number of assets the account created.
+	TotalCreatedAssets int64 `parquet:"name=total_created_assets, type=INT64"`
+}
+
+// NewTransactionRow flattens an idb.TxnRow into the transactions schema.
+func NewTransactionRow(row idb.TxnRow) (TransactionRow, error) {
+	if row.Error != nil {
+		return TransactionRow{}, row.Error
+	}
+
+	stxn := row.Txn
+	if stxn == nil {
+		stxn = row.RootTxn
+	}
+	if stxn == nil {
+		return TransactionRow{}, fmt.Errorf("transaction %d:%d is missing its transaction bytes", row.Round, row.Intra)
+	}
+
+	intra := row.Intra
+	txid := crypto.TransactionIDString(stxn.Txn)
+	if row.Extra.RootIntra.Present {
+		intra = int(row.Extra.RootIntra.Value)
+		txid = row.Extra.RootTxid
+	}
+
+	txn := stxn.Txn
+	out := TransactionRow{
+		Round:         int64(row.Round),
+		Intra:         int32(intra),
+		RoundTime:     row.RoundTime.Unix(),
+		Txid:          txid,
+		TxType:        string(txn.Type),
+		Sender:        txn.Sender.String(),
+		FeeMicroalgos: int64(txn.Fee),
+		AssetID:       int64(row.AssetID),
+	}
+
+	switch txn.Type {
+	case sdk.PaymentTx:
+		out.PaymentReceiver = txn.Receiver.String()
+		out.PaymentAmountMicroalgos = int64(txn.Amount)
+		out.PaymentCloseAmountMicroalgos = int64(stxn.ClosingAmount)
+		if !txn.CloseRemainderTo.IsZero() {
+			out.PaymentCloseTo = txn.CloseRemainderTo.String()
+		}
+	case sdk.AssetTransferTx:
+		out.AssetTransferReceiver = txn.AssetReceiver.String()
+		out.AssetTransferAmount = int64(txn.AssetAmount)
+		out.AssetTransferCloseAmount = int64(stxn.AssetClosingAmount)
+		if !txn.AssetCloseTo.IsZero() {
+			out.AssetTransferCloseTo = txn.AssetCloseTo.String()
+		}
+	case sdk.ApplicationCallTx:
+		out.ApplicationID = int64(txn.ApplicationID)
+	}
+
+	return out, nil
+}
+
+// NewAccountRow flattens a models.Account snapshot into the accounts schema.
+func NewAccountRow(acct models.Account) AccountRow {
+	return AccountRow{
+		Round:                    int64(acct.Round),
+		Address:                  acct.Address,
+		AmountMicroalgos:         int64(acct.Amount),
+		PendingRewardsMicroalgos: int64(acct.PendingRewards),
+		RewardsMicroalgos:        int64(acct.Rewards),
+		Status:                   acct.Status,
+		TotalAppsOptedIn:         int64(acct.TotalAppsOptedIn),
+		TotalAssetsOptedIn:       int64(acct.TotalAssetsOptedIn),
+		TotalCreatedApps:         int64(acct.TotalCreatedApps),
+		TotalCreatedAssets:       int64(acct.TotalCreatedAssets),
+	}
+}
@@ -0,0 +1,148 @@
+package parquetexport
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// defaultParallelism is the number of goroutines the underlying
+// parquet-go writer uses to encode row groups.
+const defaultParallelism = 4
+
+// PartitionedWriter writes TransactionRows into a sequence of Parquet
+// files under dir, each covering at most partitionRounds rounds, named
+// transactions/round=<first>-<last>.parquet. Splitting by round range
+// keeps individual files small enough for Spark/BigQuery to load in
+// parallel without indexer having to know the caller's desired file size.
+type PartitionedWriter struct {
+	dir             string
+	partitionRounds uint64
+
+	cur          *writer.ParquetWriter
+	curFile      source.ParquetFile
+	partitionMin uint64
+	partitionMax uint64
+}
+
+// NewPartitionedWriter returns a writer that partitions transaction rows
+// written to it into round-range files of partitionRounds rounds each,
+// under dir/transactions/. dir must already exist.
+func NewPartitionedWriter(dir string, partitionRounds uint64) (*PartitionedWriter, error) {
+	if partitionRounds == 0 {
+		return nil, fmt.Errorf("partitionRounds must be greater than zero")
+	}
+	if err := ensureDir(filepath.Join(dir, "transactions")); err != nil {
+		return nil, err
+	}
+	return &PartitionedWriter{dir: dir, partitionRounds: partitionRounds}, nil
+}
+
+// WriteTransaction appends row to the partition file for its round,
+// rotating to a new partition file when the round crosses a partition
+// boundary.
+func (w *PartitionedWriter) WriteTransaction(row TransactionRow) error {
+	round := uint64(row.Round)
+	partitionStart := (round / w.partitionRounds) * w.partitionRounds
+	partitionEnd := partitionStart + w.partitionRounds - 1
+
+	if w.cur == nil || partitionStart != w.partitionMin {
+		if err := w.rotate(partitionStart, partitionEnd); err != nil {
+			return err
+		}
+	}
+
+	return w.cur.Write(row)
+}
+
+func (w *PartitionedWriter) rotate(partitionMin, partitionMax uint64) error {
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("round=%d-%d.parquet", partitionMin, partitionMax)
+	path := filepath.Join(w.dir, "transactions", name)
+
+	fw, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", path, err)
+	}
+
+	pw, err := writer.NewParquetWriter(fw, new(TransactionRow), defaultParallelism)
+	if err != nil {
+		fw.Close()
+		return fmt.Errorf("could not create parquet writer for %s: %w", path, err)
+	}
+
+	w.cur = pw
+	w.curFile = fw
+	w.partitionMin = partitionMin
+	w.partitionMax = partitionMax
+	return nil
+}
+
+func (w *PartitionedWriter) closeCurrent() error {
+	if w.cur == nil {
+		return nil
+	}
+	if err := w.cur.WriteStop(); err != nil {
+		w.curFile.Close()
+		return fmt.Errorf("could not finalize parquet file: %w", err)
+	}
+	err := w.curFile.Close()
+	w.cur = nil
+	w.curFile = nil
+	return err
+}
+
+// Close flushes and closes the current partition file, if any.
+func (w *PartitionedWriter) Close() error {
+	return w.closeCurrent()
+}
+
+// AccountWriter writes AccountRows into a single Parquet file under
+// dir/accounts/accounts.parquet. Unlike transactions, an account snapshot
+// has no natural round range to partition by, so it's kept as one file.
+type AccountWriter struct {
+	file source.ParquetFile
+	pw   *writer.ParquetWriter
+}
+
+// NewAccountWriter creates dir/accounts/accounts.parquet and returns a
+// writer for it. dir must already exist.
+func NewAccountWriter(dir string) (*AccountWriter, error) {
+	if err := ensureDir(filepath.Join(dir, "accounts")); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, "accounts", "accounts.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s: %w", path, err)
+	}
+
+	pw, err := writer.NewParquetWriter(fw, new(AccountRow), defaultParallelism)
+	if err != nil {
+		fw.Close()
+		return nil, fmt.Errorf("could not create parquet writer for %s: %w", path, err)
+	}
+
+	return &AccountWriter{file: fw, pw: pw}, nil
+}
+
+// WriteAccount appends row to the accounts file.
+func (w *AccountWriter) WriteAccount(row AccountRow) error {
+	return w.pw.Write(row)
+}
+
+// Close flushes and closes the accounts file.
+func (w *AccountWriter) Close() error {
+	if err := w.pw.WriteStop(); err != nil {
+		w.file.Close()
+		return fmt.Errorf("could not finalize accounts parquet file: %w", err)
+	}
+	return w.file.Close()
+}
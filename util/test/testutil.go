@@ -57,6 +57,15 @@ func myStackTrace() {
 	}
 }
 
+// Fail prints msg to stderr along with a short stack trace and marks the
+// overall run as failed, for a caller running its own check that doesn't fit
+// one of the PrintXQuery helpers.
+func Fail(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, a...)
+	myStackTrace()
+	exitValue = 1
+}
+
 // PrintAssetQuery prints information about an asset query.
 func PrintAssetQuery(db idb.IndexerDb, q idb.AssetsQuery) {
 	count := uint64(0)
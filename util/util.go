@@ -93,6 +93,24 @@ func PrintableUTF8OrEmpty(in string) string {
 	return in
 }
 
+// SanitizePrintableUTF8 returns a copy of in with every invalid or
+// non-printable rune dropped, so the result is always valid UTF8 even when in
+// is raw bytes decoded as a string (e.g. an asset name or unit name, which
+// Algorand allows to be arbitrary bytes). Unlike PrintableUTF8OrEmpty, a
+// single bad rune doesn't discard the rest of the string, which makes this
+// suitable for building a best-effort search projection of a field that's
+// kept verbatim elsewhere for display.
+func SanitizePrintableUTF8(in string) string {
+	var b strings.Builder
+	for _, c := range in {
+		if c == utf8.RuneError || !unicode.IsPrint(c) {
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
 // KeysStringBool returns all of the keys in the map joined by a comma.
 func KeysStringBool(m map[string]bool) string {
 	keys := make([]string, 0, len(m))